@@ -0,0 +1,70 @@
+package cmd
+
+// Copyright 2019 The Go Authors. All rights reserved.
+// Use of this session code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"sr/util"
+	"strconv"
+	"strings"
+)
+
+// Explain implements the Explain cmd.
+type Explain struct {
+}
+
+func (e *Explain) Name() string      { return "explain" }
+func (e *Explain) Usage() string     { return "<generated-file>:<line>" }
+func (e *Explain) ShortHelp() string { return "print the declaration a generated line comes from" }
+func (e *Explain) DetailedHelp(f *flag.FlagSet) {
+	fmt.Fprint(f.Output(), `
+e.g. sr explain internal/srpc/slot/order.go:42
+`)
+	f.PrintDefaults()
+}
+
+// Run prints the source declaration of a generated line to stdout.
+func (e *Explain) Run(ctx context.Context, args ...string) error {
+	if len(args) != 1 {
+		return errors.New("argument error, e.g. internal/srpc/slot/order.go:42")
+	}
+	index := strings.LastIndex(args[0], ":")
+	if index <= 0 {
+		return errors.New("argument error, e.g. internal/srpc/slot/order.go:42")
+	}
+	filename := args[0][:index]
+	line, err := strconv.Atoi(args[0][index+1:])
+	if err != nil {
+		return fmt.Errorf("line %s is not a number", args[0][index+1:])
+	}
+	smap, err := util.ReadSourceMap(filename)
+	if err != nil {
+		return fmt.Errorf("read source map of %s error: %s", filename, err.Error())
+	}
+	mapping := smap.Find(line)
+	if mapping == nil {
+		return fmt.Errorf("%s:%d is not generated from a declaration", filename, line)
+	}
+	root, err := util.FindProjectRoot(filepath.Dir(filename))
+	if err != nil {
+		return err
+	}
+	source := filepath.Join(root, filepath.FromSlash(mapping.Source))
+	fmt.Printf("%s:%d:%d: %s\n", util.TryConvRelPath(root, source), mapping.Line, mapping.Column, mapping.Name)
+	data, err := ioutil.ReadFile(source)
+	if err != nil {
+		return err
+	}
+	lines := strings.Split(strings.ReplaceAll(string(data), "\r\n", "\n"), "\n")
+	for i := mapping.Line; i <= mapping.SourceLine && i <= len(lines); i++ {
+		fmt.Printf("%5d | %s\n", i, lines[i-1])
+	}
+	return nil
+}
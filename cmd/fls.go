@@ -9,9 +9,10 @@ import (
 	"errors"
 	"flag"
 	"fmt"
+	"regexp"
+	"sr/emit"
+	"sr/parse"
 	"strings"
-
-	"github.com/aundis/meta"
 )
 
 // Version implements the Version cmd.
@@ -56,14 +57,21 @@ func (f *Fls) Run(ctx context.Context, args ...string) error {
 	return nil
 }
 
-func printFunction(fmeta *meta.FunctionMeta) {
+func printFunction(fmeta *emit.FunctionMeta) {
+	// 文档注释输出在函数上方
+	if len(fmeta.Doc) > 0 {
+		fmt.Print(parse.DocComment(fmeta.Doc))
+	}
+	if len(fmeta.Deprecated) > 0 {
+		fmt.Print(parse.DocComment("Deprecated: " + fmeta.Deprecated))
+	}
 	fmt.Print(fmeta.Name, " (")
 	for i, p := range fmeta.Parameters {
 		if i != 0 {
 			fmt.Print(", ")
 		}
 		fmt.Print(p.Name, " ")
-		fmt.Print(p.Type)
+		fmt.Print(formatFieldType(p))
 	}
 	fmt.Print(") ")
 	if len(fmeta.Results) > 1 {
@@ -76,10 +84,50 @@ func printFunction(fmeta *meta.FunctionMeta) {
 		if len(r.Name) > 0 {
 			fmt.Print(r.Name, " ")
 		}
-		fmt.Print(r.Type)
+		fmt.Print(formatFieldType(r))
 	}
 	if len(fmeta.Results) > 1 {
 		fmt.Print(")")
 	}
 	fmt.Print("\n")
+	// 打印用到的枚举类型的可选值
+	printed := map[string]bool{}
+	var fields []*emit.FieldMeta
+	fields = append(fields, fmeta.Parameters...)
+	fields = append(fields, fmeta.Results...)
+	for _, field := range fields {
+		for _, tmeta := range field.TypeMetas {
+			if len(tmeta.Values) == 0 || printed[tmeta.Id] {
+				continue
+			}
+			printed[tmeta.Id] = true
+			var values []string
+			for _, v := range tmeta.Values {
+				values = append(values, v.Name+"="+v.Value)
+			}
+			fmt.Printf("    %s: %s\n", tmeta.Name, strings.Join(values, ", "))
+		}
+	}
+}
+
+var typeMetaIdReg = regexp.MustCompile(`\{\{(.+?)\}\}`)
+
+// formatFieldType 将类型中的 {{id}} 占位符替换为类型名称
+func formatFieldType(field *emit.FieldMeta) string {
+	return typeMetaIdReg.ReplaceAllStringFunc(field.Type, func(s string) string {
+		id := s[2 : len(s)-2]
+		for _, tmeta := range field.TypeMetas {
+			if tmeta.Id != id {
+				continue
+			}
+			if tmeta.Import != nil {
+				if len(tmeta.Import.Alias) > 0 {
+					return tmeta.Import.Alias + "." + tmeta.Name
+				}
+				return tmeta.Import.Path[strings.LastIndex(tmeta.Import.Path, "/")+1:] + "." + tmeta.Name
+			}
+			return tmeta.Name
+		}
+		return s
+	})
 }
@@ -11,10 +11,20 @@ import (
 	"fmt"
 	"os"
 	"sr/emit"
+	"strings"
 )
 
 // Version implements the Version cmd.
 type Gen struct {
+	TypeCheck bool   `flag:"typecheck" help:"resolve types with go/types, supports aliases, dot imports and embedded interfaces"`
+	Tags      string `flag:"tags" help:"a comma-separated list of additional build tags to consider satisfied while scanning sources"`
+	Strict    bool   `flag:"strict" help:"require an explicit json tag on every exported field of transported structs"`
+	Methods   bool   `flag:"methods" help:"copy self-contained methods of transported types to callers, such as MarshalJSON and Validate"`
+	Contract  string `flag:"contract" help:"generate a standalone contract module with call interfaces and types of slots into the directory"`
+	WireKeys  string `flag:"wirekeys" help:"keys of parameters and results on the wire: name (default), position (legacy p1/r1) or compat (send both, accept either during migration)"`
+	Timeout   string `flag:"timeout" help:"default execution time limit of slot methods (e.g. 30s), overridden by the //sr:timeout directive"`
+	SlotDirs  string `flag:"slotdirs" help:"a comma-separated list of directories outside internal/logic (e.g. internal/billing/...) to scan for sXxx structs embedding meta.Slot, registered with manager.Provide"`
+	Vendor    string `flag:"vendor" help:"a comma-separated list of third-party package patterns (e.g. github.com/foo/dto/...) whose types are copied to callers instead of imported"`
 }
 
 func (g *Gen) Name() string      { return "gen" }
@@ -26,7 +36,7 @@ func (g *Gen) DetailedHelp(f *flag.FlagSet) {
 }
 
 // Run prints Version information to stdout.
-func (g *Gen) Run(_ context.Context, args ...string) error {
+func (g *Gen) Run(ctx context.Context, args ...string) error {
 	dir, err := os.Getwd()
 	if err != nil {
 		return err
@@ -37,15 +47,51 @@ func (g *Gen) Run(_ context.Context, args ...string) error {
 	if len(args) > 1 {
 		return errors.New("augument too more")
 	}
+	option, err := readGenOption(ctx)
+	if err != nil {
+		return err
+	}
+	// 命令行参数优先
+	if g.TypeCheck {
+		option.TypeCheck = true
+	}
+	if g.Strict {
+		option.Strict = true
+	}
+	if len(g.Tags) > 0 {
+		option.Tags = strings.Split(g.Tags, ",")
+	}
+	if g.Methods {
+		option.Methods = true
+	}
+	if len(g.Contract) > 0 {
+		option.Contract = g.Contract
+	}
+	if len(g.Vendor) > 0 {
+		option.Vendor = strings.Split(g.Vendor, ",")
+	}
+	if len(g.SlotDirs) > 0 {
+		option.SlotDirs = strings.Split(g.SlotDirs, ",")
+	}
+	if len(g.WireKeys) > 0 {
+		option.WireKeys = g.WireKeys
+	}
+	if len(g.Timeout) > 0 {
+		option.Timeout = g.Timeout
+	}
+	err = emit.CheckOption(option)
+	if err != nil {
+		return err
+	}
 	switch args[0] {
 	case "slot":
-		err = emit.EmitSlot(dir)
+		err = emit.EmitSlot(dir, option)
 	case "signal":
-		err = emit.EmitSignal(dir)
+		err = emit.EmitSignal(dir, option)
 	case "call":
-		err = emit.EmitCall(dir)
+		err = emit.EmitCall(dir, option)
 	case "listen":
-		err = emit.EmitListen(dir)
+		err = emit.EmitListen(dir, option)
 	default:
 		return fmt.Errorf("argument %s not in [call/signal/slot/listen]", args[0])
 	}
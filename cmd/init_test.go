@@ -1,6 +1,11 @@
 package cmd
 
 import (
+	"bytes"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
 	"testing"
 
 	_ "sr/packed"
@@ -26,3 +31,65 @@ func TestInit(t *testing.T) {
 		return
 	}
 }
+
+// TestInitProject 在临时的模块中生成模板, 加入 testdata/project 中的测试后运行生成项目的测试
+func TestInitProject(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skip building generated project in short mode")
+	}
+	if _, err := exec.LookPath("go"); err != nil {
+		t.Skip("go command not found")
+	}
+	root := t.TempDir()
+	cmd := &Init{
+		variable: map[string]string{"module-name": "abc"},
+	}
+	if err := cmd.build(root); err != nil {
+		t.Error(err)
+		return
+	}
+	// 生成的项目与 sr 使用相同的依赖
+	mod, err := os.ReadFile("../go.mod")
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	mod = bytes.Replace(mod, []byte("module sr"), []byte("module abc"), 1)
+	sum, err := os.ReadFile("../go.sum")
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	if err := os.WriteFile(filepath.Join(root, "go.mod"), mod, os.ModePerm); err != nil {
+		t.Error(err)
+		return
+	}
+	if err := os.WriteFile(filepath.Join(root, "go.sum"), sum, os.ModePerm); err != nil {
+		t.Error(err)
+		return
+	}
+	err = filepath.Walk("testdata/project", func(path string, info os.FileInfo, err error) error {
+		if err != nil || info.IsDir() {
+			return err
+		}
+		content, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		rel, err := filepath.Rel("testdata/project", path)
+		if err != nil {
+			return err
+		}
+		return os.WriteFile(filepath.Join(root, strings.TrimSuffix(rel, ".txt")), content, os.ModePerm)
+	})
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	run := exec.Command("go", "test", "./...")
+	run.Dir = root
+	run.Env = append(os.Environ(), "GOFLAGS=-mod=mod")
+	if out, err := run.CombinedOutput(); err != nil {
+		t.Errorf("except generated project test pass but got %v\n%s", err, out)
+	}
+}
@@ -1,6 +1,6 @@
 package cmd
 
-import "github.com/aundis/meta"
+import "sr/emit"
 
 type helperListReq struct {
 	Name string `json:"name"`
@@ -8,5 +8,5 @@ type helperListReq struct {
 }
 
 type helperListRes struct {
-	List []meta.ObjectMeta `json:"list"`
+	List []emit.ObjectMeta `json:"list"`
 }
@@ -6,14 +6,14 @@ import (
 	"errors"
 	"fmt"
 	"net/http"
+	"sr/emit"
 
-	"github.com/aundis/meta"
 	"github.com/aundis/srpc"
 	"github.com/gogf/gf/v2/frame/g"
 	"github.com/gogf/gf/v2/net/gclient"
 )
 
-func requestObjectMeta(ctx context.Context, client *srpc.Client, target string, req helperListReq) ([]meta.ObjectMeta, error) {
+func requestObjectMeta(ctx context.Context, client *srpc.Client, target string, req helperListReq) ([]emit.ObjectMeta, error) {
 	data, err := json.Marshal(req)
 	if err != nil {
 		return nil, err
@@ -60,3 +60,23 @@ func readConfig(ctx context.Context) (addr string, err error) {
 	addr = addrValue.String()
 	return
 }
+
+// readGenOption 读取配置文件中 srpc.gen 节点的生成选项, 没有配置文件时使用默认值
+func readGenOption(ctx context.Context) (option emit.Option, err error) {
+	if !g.Cfg().Available(ctx) {
+		return
+	}
+	value, err := g.Cfg().Get(ctx, "srpc.gen")
+	if err != nil {
+		err = errors.New("read srpc gen option error: " + err.Error())
+		return
+	}
+	if value.IsNil() {
+		return
+	}
+	err = value.Scan(&option)
+	if err != nil {
+		err = errors.New("read srpc gen option error: " + err.Error())
+	}
+	return
+}
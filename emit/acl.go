@@ -0,0 +1,54 @@
+package emit
+
+import (
+	"sr/parse"
+	"sr/util"
+	"strconv"
+	"strings"
+)
+
+// 允许调用的服务可以在 meta.Slot 的标签上声明, 对整个对象生效, e.g. meta.Slot `allow:"billing,gateway"`,
+// 也可以在方法上使用 //sr:allow 指令声明, 方法上的声明优先, 运行时还会合并配置 srpc.allow 中的声明
+
+// slotAllowedCallers 对象上声明的允许的调用方
+func slotAllowedCallers(st *parse.StructType) []string {
+	return splitCallers(slotTag(st).Get("allow"))
+}
+
+// allowedCallers 方法上声明的允许的调用方, 多条指令合并
+func allowedCallers(fun *parse.Function) []string {
+	var result []string
+	for _, d := range fun.Directives {
+		if d.Name == directiveAllow {
+			result = append(result, splitCallers(d.Args)...)
+		}
+	}
+	return result
+}
+
+func splitCallers(s string) []string {
+	return strings.FieldsFunc(s, func(r rune) bool {
+		return r == ',' || r == ' ' || r == '\t'
+	})
+}
+
+// emitAllowCallers 注册声明的调用方, e.g. manager.AllowCallers("Order", "billing", "gateway")
+func emitAllowCallers(writer util.TextWriter, name string, callers []string) {
+	if len(callers) == 0 {
+		return
+	}
+	writer.WriteString(`manager.AllowCallers("`, name, `"`)
+	for _, caller := range callers {
+		writer.WriteString(", ", strconv.Quote(caller))
+	}
+	writer.WriteString(")").WriteLine()
+}
+
+// stringSliceCode 字符串切片的代码, e.g. []string{"billing", "gateway"}
+func stringSliceCode(list []string) string {
+	quoted := make([]string, len(list))
+	for i, v := range list {
+		quoted[i] = strconv.Quote(v)
+	}
+	return "[]string{" + strings.Join(quoted, ", ") + "}"
+}
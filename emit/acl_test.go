@@ -0,0 +1,43 @@
+package emit
+
+import (
+	"sr/parse"
+	"strings"
+	"testing"
+)
+
+func TestAllowedCallers(t *testing.T) {
+	code := "package p\n\ntype sOrder struct {\n\tmeta.Slot `allow:\"billing, gateway\"`\n}\n\n" +
+		"//sr:allow gateway\n//sr:allow ops,audit\nfunc (s *sOrder) Create() {}\n\nfunc (s *sOrder) List() {}"
+	f, err := parse.ParseContent("test.go", []byte(code))
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	st := parse.CombineStructTypes([]*parse.File{f})[0]
+	if callers := strings.Join(slotAllowedCallers(st), ","); callers != "billing,gateway" {
+		t.Errorf("except object callers billing,gateway but got %s", callers)
+		return
+	}
+	if callers := strings.Join(allowedCallers(st.Functions[0]), ","); callers != "gateway,ops,audit" {
+		t.Errorf("except method callers gateway,ops,audit but got %s", callers)
+		return
+	}
+	if callers := allowedCallers(st.Functions[1]); len(callers) > 0 {
+		t.Errorf("except no method callers but got %v", callers)
+		return
+	}
+	// disallow 不是 allow
+	f, err = parse.ParseContent("test.go", []byte("package p\n\ntype sOrder struct {\n\tmeta.Slot `disallow:\"billing\"`\n}"))
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	if callers := slotAllowedCallers(f.StructTypes[0]); len(callers) > 0 {
+		t.Errorf("except no object callers but got %v", callers)
+		return
+	}
+	if code := stringSliceCode([]string{"billing", "gateway"}); code != `[]string{"billing", "gateway"}` {
+		t.Errorf("except code []string{\"billing\", \"gateway\"} but got %s", code)
+	}
+}
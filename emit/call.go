@@ -6,11 +6,9 @@ import (
 	"sr/parse"
 	"sr/util"
 	"strconv"
-
-	"github.com/aundis/meta"
 )
 
-func EmitCall(root string) error {
+func EmitCall(root string, option ...Option) error {
 	// 取项目模块名
 	module, err := getProjectModuleName(root)
 	if err != nil {
@@ -26,8 +24,9 @@ func EmitCall(root string) error {
 	if err != nil {
 		return err
 	}
+	opt := firstOption(option)
 	for _, dir := range dirs {
-		err = emitCallDir(root, module, dir)
+		err = emitCallDir(root, module, dir, opt)
 		if err != nil {
 			return err
 		}
@@ -54,7 +53,7 @@ func EmitCall(root string) error {
 	return nil
 }
 
-func emitCallDir(root, module string, dir string) error {
+func emitCallDir(root, module string, dir string, option Option) error {
 	base := path.Base(dir)
 	// 删除历史生成的目录
 	outPath := path.Join(dir, "call")
@@ -83,19 +82,27 @@ func emitCallDir(root, module string, dir string) error {
 		return nil
 	}
 	// 拿到所有的接口类型
-	var interfaceTypes []*parse.InterfaceType
+	var astFiles []*parse.File
 	for _, filename := range goFiles {
 		astFile, err := parse.ParseFile(filename)
 		if err != nil {
 			return err
 		}
-		for _, it := range astFile.InterfaceTypes {
-			// 只处理I开头的interface
-			if !(len(it.Name) > 0 && it.Name[0] == 'I') {
-				continue
-			}
-			interfaceTypes = append(interfaceTypes, it)
+		astFiles = append(astFiles, astFile)
+	}
+	resolver := newTypeResolver(root, module, option)
+	var interfaceTypes []*parse.InterfaceType
+	for _, it := range parse.CombineInterfaceTypes(astFiles) {
+		// 只处理I开头的interface
+		if !(len(it.Name) > 0 && it.Name[0] == 'I') {
+			continue
+		}
+		// 展开其他文件或其他包中的嵌入接口
+		err = resolver.expandInterface(it)
+		if err != nil {
+			return err
 		}
+		interfaceTypes = append(interfaceTypes, it)
 	}
 	// 内容不生成文件
 	if len(interfaceTypes) == 0 {
@@ -104,7 +111,7 @@ func emitCallDir(root, module string, dir string) error {
 	// 生成
 	for _, it := range interfaceTypes {
 		target := base
-		err = emitCallStruct(root, module, target, it)
+		err = emitCallStruct(root, module, target, it, option)
 		if err != nil {
 			return err
 		}
@@ -112,19 +119,17 @@ func emitCallDir(root, module string, dir string) error {
 	return nil
 }
 
-func emitCallStruct(root, module, target string, it *parse.InterfaceType) error {
+func emitCallStruct(root, module, target string, it *parse.InterfaceType, option Option) error {
 	e := &callStructEmiter{
-		writer: util.NewTextWriter(),
-		root:   root,
-		module: module,
-		target: target,
-		resolver: &typeResolver{
-			module:   module,
-			root:     root,
-			resolved: map[string]*meta.TypeMeta{},
-		},
+		writer:   util.NewTextWriter(),
+		root:     root,
+		module:   module,
+		target:   target,
+		option:   option,
+		resolver: newTypeResolver(root, module, option),
 		exportTo: fmt.Sprintf("%s/internal/srpc/service/%s/call", module, target),
 		it:       it,
+		smap:     util.NewSourceMap(),
 	}
 	return e.emit()
 }
@@ -134,9 +139,12 @@ type callStructEmiter struct {
 	module   string
 	target   string
 	exportTo string
+	option   Option
 	resolver *typeResolver
+	imports  *importCollect
 	it       *parse.InterfaceType
 	writer   util.TextWriter
+	smap     *util.SourceMap
 }
 
 func (e *callStructEmiter) emit() error {
@@ -159,6 +167,10 @@ func (e *callStructEmiter) emit() error {
 	if err != nil {
 		return err
 	}
+	err = util.WriteSourceMap(outPath, e.smap)
+	if err != nil {
+		return err
+	}
 	return nil
 }
 
@@ -174,25 +186,39 @@ func (e *callStructEmiter) emitHeader() error {
 }
 
 func (e *callStructEmiter) emitImports() error {
-	collect := newImportCollect()
+	collect := newImportCollect(e.root)
 	collect.Set("context", "context")
 	collect.Set("json", "encoding/json")
 	collect.Set("srpc", "github.com/aundis/srpc")
 	collect.Set("service", e.module+"/internal/service")
 	collect.Set(e.target, e.module+"/internal/srpc/service/"+e.target)
-	err := resolveInterfaceImports(e.it, collect, e.exportTo, e.module, e.root)
+	if e.needRenameKeys() {
+		collect.Set("manager", e.module+"/internal/srpc/manager")
+	}
+	err := resolveInterfaceImports(e.it, collect, e.exportTo, e.module, e.root, e.option)
 	if err != nil {
 		return err
 	}
 	collect.Emit(e.writer)
+	e.imports = collect
 	return nil
 }
 
+// needRenameKeys 兼容模式下有返回值需要转换位置键时导入 manager
+func (e *callStructEmiter) needRenameKeys() bool {
+	for _, fun := range e.it.Functions {
+		if len(fun.Results) > 1 && len(renameKeysCode(e.option, fun.Results[:len(fun.Results)-1], "r")) > 0 {
+			return true
+		}
+	}
+	return false
+}
+
 func (e *callStructEmiter) emitBody() error {
 	it := e.it
 	writer := e.writer
 	// 解析重定向所有的Field
-	fResolver := newFieldResolver(e.root, e.module, e.exportTo)
+	fResolver := newFieldResolver(e.root, e.module, e.exportTo, e.imports, e.option)
 	fields := getInterfaceFields(it)
 	for _, v := range fields {
 		err := fResolver.resolve(v)
@@ -216,31 +242,32 @@ func (e *callStructEmiter) emitBody() error {
 	for _, fun := range it.Functions {
 		// 先生成返回类型的结构体, 如果有返回值的话
 		responseStructName := firstLower(e.target) + it.Name[1:] + fun.Name + "Response"
+		writer.WriteEmptyLine()
+		start := writer.Line()
 		if len(fun.Results) > 1 {
-			writer.WriteEmptyLine()
 			writer.WriteString("type ", responseStructName, " struct {").WriteLine().IncreaseIndent()
 			for i, r := range fun.Results {
 				if i == len(fun.Results)-1 {
 					continue
 				}
-				name := "r" + strconv.Itoa(i+1)
-				writer.WriteString(firstUpper(name), " ", fResolver.getResolvedType(r), " `json:\"", name, "\"`").WriteLine()
+				name := "R" + strconv.Itoa(i+1)
+				writer.WriteString(name, " ", fResolver.getResolvedType(r), " `json:\"", wireKey(e.option, r, "r", i+1), "\"`").WriteLine()
 			}
 			writer.DecreaseIndent().WriteString("}").WriteLine()
+			writer.WriteEmptyLine()
 		}
 
 		// writer.WriteString(fmt.Sprintf("func (c *%s) %s (", structName, m.Name))
-		writer.WriteEmptyLine()
 		writer.WriteString("func (c *", structName, ") ", fun.Name, " (")
 		// 写参数
 		for i, p := range fun.Params {
 			// 首参数校验
 			if i == 0 {
 				if p.Name != "ctx" {
-					return formatError(it.Parent.FileSet, p.Pos, "first param name must is ctx", e.root)
+					return formatError(fun.Parent.FileSet, p.Pos, "first param name must is ctx", e.root)
 				}
 				if p.Type != "context.Context" {
-					return formatError(it.Parent.FileSet, p.Pos, "first param type must is context.Context", e.root)
+					return formatError(fun.Parent.FileSet, p.Pos, "first param type must is context.Context", e.root)
 				}
 			}
 			if i != 0 {
@@ -257,14 +284,14 @@ func (e *callStructEmiter) emitBody() error {
 		writer.WriteString(")")
 		// 写返回值
 		if len(fun.Results) == 0 {
-			return formatError(it.Parent.FileSet, fun.Pos, "method must provide a return value of type error", e.root)
+			return formatError(fun.Parent.FileSet, fun.Pos, "method must provide a return value of type error", e.root)
 		}
 		writer.WriteString(" (")
 		for i, r := range fun.Results {
 			// 校验最后一个返回类型
 			if i == len(fun.Results)-1 {
 				if r.Type != "error" {
-					return formatError(it.Parent.FileSet, fun.Pos, "method last return value must be error", e.root)
+					return formatError(fun.Parent.FileSet, fun.Pos, "method last return value must be error", e.root)
 				}
 			}
 			if i != 0 {
@@ -286,12 +313,14 @@ func (e *callStructEmiter) emitBody() error {
 		// 	})
 		writer.WriteString("data, err := json.Marshal(map[string]interface{}{").WriteLine().IncreaseIndent()
 		if len(fun.Params) > 1 {
-			for i := range fun.Params {
+			for i, p := range fun.Params {
 				if i == 0 {
 					continue
 				}
 				name := "p" + strconv.Itoa(i)
-				writer.WriteString(`"`, name, `": `, name, ",").WriteLine()
+				for _, key := range sendKeys(e.option, p, "p", i) {
+					writer.WriteString(`"`, key, `": `, name, ",").WriteLine()
+				}
 			}
 		}
 		writer.DecreaseIndent().WriteString("})").WriteLine()
@@ -331,6 +360,13 @@ func (e *callStructEmiter) emitBody() error {
 			// 	if err != nil {
 			// 		return 0, 0, err
 			// 	}
+			// 兼容旧版本服务端返回的位置键
+			if keys := renameKeysCode(e.option, fun.Results[:len(fun.Results)-1], "r"); len(keys) > 0 {
+				writer.WriteString("res, err = manager.RenameKeys(res, ", keys, ")").WriteLine()
+				writer.WriteString("if err != nil {").WriteLine().IncreaseIndent()
+				writer.WriteString("return").WriteLine()
+				writer.DecreaseIndent().WriteString("}").WriteLine()
+			}
 			writer.WriteString("var rsp *").WriteString(responseStructName).WriteLine()
 			writer.WriteString("err = json.Unmarshal(res, &rsp)").WriteLine()
 			writer.WriteString("if err != nil {").WriteLine().IncreaseIndent()
@@ -341,11 +377,12 @@ func (e *callStructEmiter) emitBody() error {
 					continue
 				}
 				name := "r" + strconv.Itoa(i+1)
-				writer.WriteString(name, " = ", "rsp.", firstUpper(name)).WriteLine()
+				writer.WriteString(name, " = ", "rsp.R", strconv.Itoa(i+1)).WriteLine()
 			}
 		}
 		writer.WriteString("return").WriteLine()
 		writer.DecreaseIndent().WriteString("}").WriteLine()
+		addSourceMapping(e.smap, writer, start, fun.Parent.FileSet, fun.Pos, fun.End, it.Name+"."+fun.Name, e.root)
 	}
 	return nil
 }
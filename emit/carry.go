@@ -0,0 +1,260 @@
+package emit
+
+import (
+	"fmt"
+	"go/ast"
+	"go/types"
+	"sr/parse"
+)
+
+// carryTypeDecls 复制与类型关联的常量块和方法, 无法独立复制的声明会被跳过并给出警告
+func (r *typeResolver) carryTypeDecls(modelType *parse.ModelType) (string, error) {
+	name := modelTypeName(modelType)
+	// 声明中可以直接引用的标识符: 类型本身和它的常量
+	allowed := map[string]bool{name: true}
+	for _, block := range modelType.Consts {
+		for _, c := range block.Consts {
+			allowed[c.Name] = true
+		}
+	}
+	var code string
+	for _, block := range modelType.Consts {
+		carried, err := r.carryDecl(block.Parent, block.Raw.(*ast.GenDecl), allowed)
+		if err != nil {
+			printWarning(err.Error() + ", ignore constants of " + name)
+			continue
+		}
+		code += "\n\n" + carried
+	}
+	var methods []*parse.Function
+	carried := map[*parse.Function]string{}
+	skipped := map[string]bool{}
+	for _, fun := range modelType.Methods {
+		decl := findFuncDecl(fun)
+		if decl == nil {
+			continue
+		}
+		method, err := r.carryDecl(fun.Parent, decl, allowed)
+		if err != nil {
+			printWarning(err.Error() + ", ignore method " + name + "." + fun.Name)
+			skipped[fun.Name] = true
+			continue
+		}
+		methods = append(methods, fun)
+		carried[fun] = method
+	}
+	// 调用了被跳过的方法的方法同样不能复制, 直到没有新的方法被跳过
+	for changed := true; changed; {
+		changed = false
+		for _, fun := range methods {
+			if skipped[fun.Name] {
+				continue
+			}
+			if call := findMethodCall(findFuncDecl(fun), skipped); call != nil {
+				msg := fmt.Sprintf("call to method %s cannot be copied, ignore method %s.%s", call.Sel.Name, name, fun.Name)
+				printWarning(formatError(fun.Parent.FileSet, call.Pos(), msg, r.root).Error())
+				skipped[fun.Name] = true
+				changed = true
+			}
+		}
+	}
+	for _, fun := range methods {
+		if !skipped[fun.Name] {
+			code += "\n\n" + parse.DocComment(fun.Doc) + carried[fun]
+		}
+	}
+	return code, nil
+}
+
+func withRecvTypeParams(allowed map[string]bool, recv *ast.FieldList) map[string]bool {
+	var params []ast.Expr
+	for _, field := range recv.List {
+		expr := field.Type
+		if star, ok := expr.(*ast.StarExpr); ok {
+			expr = star.X
+		}
+		switch x := expr.(type) {
+		case *ast.IndexExpr:
+			params = append(params, x.Index)
+		case *ast.IndexListExpr:
+			params = append(params, x.Indices...)
+		}
+	}
+	if len(params) == 0 {
+		return allowed
+	}
+	result := map[string]bool{}
+	for name := range allowed {
+		result[name] = true
+	}
+	for _, param := range params {
+		if id, ok := param.(*ast.Ident); ok {
+			result[id.Name] = true
+		}
+	}
+	return result
+}
+
+// findMethodCall 查找方法中对 names 中方法的调用, 只根据选择器的名称判断
+func findMethodCall(decl *ast.FuncDecl, names map[string]bool) *ast.SelectorExpr {
+	var result *ast.SelectorExpr
+	ast.Inspect(decl.Body, func(n ast.Node) bool {
+		if sel, ok := n.(*ast.SelectorExpr); ok && names[sel.Sel.Name] && result == nil {
+			result = sel
+		}
+		return result == nil
+	})
+	return result
+}
+
+// carryDecl 复制一个声明的代码, 其中引用的类型和导入包中的标识符替换为占位符
+// 声明引用了包级别的变量或函数时无法复制, 返回错误
+func (r *typeResolver) carryDecl(file *parse.File, node ast.Node, allowed map[string]bool) (string, error) {
+	// 泛型类型方法的接收者声明了类型参数, e.g. func (p *Page[T]) Len()
+	if fd, ok := node.(*ast.FuncDecl); ok && fd.Recv != nil {
+		allowed = withRecvTypeParams(allowed, fd.Recv)
+	}
+	var refs []typeRef
+	var visit func(n ast.Node) bool
+	visit = func(n ast.Node) bool {
+		switch x := n.(type) {
+		case *ast.FuncDecl:
+			// 方法名不是引用
+			if x.Recv != nil {
+				ast.Inspect(x.Recv, visit)
+			}
+			ast.Inspect(x.Type, visit)
+			if x.Body != nil {
+				ast.Inspect(x.Body, visit)
+			}
+			return false
+		case *ast.SelectorExpr:
+			if id, ok := x.X.(*ast.Ident); ok && id.Obj == nil && resolveImport(file, id.Name) != nil {
+				refs = append(refs, typeRef{scope: id.Name, name: x.Sel.Name, pos: x.Pos(), end: x.End(), expr: x})
+				return false
+			}
+			// 字段和方法名不是引用
+			ast.Inspect(x.X, visit)
+			return false
+		case *ast.KeyValueExpr:
+			// 未解析的键是结构体的字段名
+			if id, ok := x.Key.(*ast.Ident); !ok || id.Obj != nil {
+				ast.Inspect(x.Key, visit)
+			}
+			ast.Inspect(x.Value, visit)
+			return false
+		case *ast.Field:
+			ast.Inspect(x.Type, visit)
+			return false
+		case *ast.LabeledStmt:
+			ast.Inspect(x.Stmt, visit)
+			return false
+		case *ast.BranchStmt:
+			return false
+		case *ast.Ident:
+			if x.Name == "_" || allowed[x.Name] || isDeclaredIn(node, x.Obj) || types.Universe.Lookup(x.Name) != nil {
+				return false
+			}
+			// 同一个包中的类型
+			refs = append(refs, typeRef{name: x.Name, pos: x.Pos(), end: x.End(), expr: x})
+		}
+		return true
+	}
+	ast.Inspect(node, visit)
+	return rewriteTypeRefs(file.Content, node.Pos(), node.End(), refs, r.replaceCarriedRef(file))
+}
+
+func (r *typeResolver) replaceCarriedRef(file *parse.File) func(ref typeRef) (string, error) {
+	return func(ref typeRef) (string, error) {
+		loc, err := r.locateSyntax(file, ref)
+		if err != nil {
+			return "", err
+		}
+		// 复制的代码只能引用标准库和复制的类型, 否则调用方需要依赖提供方的第三方包
+		if !loc.builtin && !r.isCopied(loc.pkgPath) && !isStandardPackage(loc.pkgPath) {
+			return "", formatError(file.FileSet, ref.pos, "reference to "+ref.scope+"."+ref.name+" from third-party package "+loc.pkgPath+" cannot be copied", r.root)
+		}
+		// 本项目和复制的第三方包中只有类型能够复制
+		if r.isCopied(loc.pkgPath) {
+			model, err := parse.ParsePackageModel(loc.dir, r.option.Tags...)
+			if err != nil {
+				return "", err
+			}
+			if !model.ContainsType(loc.name) {
+				return "", formatError(file.FileSet, ref.pos, "reference to "+ref.name+" cannot be copied", r.root)
+			}
+		}
+		typeMeta, err := r.resolveRef(file, ref, loc)
+		if err != nil {
+			return "", err
+		}
+		return "{{" + typeMeta.Id + "}}", nil
+	}
+}
+
+var printedWarnings = map[string]bool{}
+
+// printWarning 打印警告, 同一个类型被多次解析时只打印一次
+func printWarning(msg string) {
+	if printedWarnings[msg] {
+		return
+	}
+	printedWarnings[msg] = true
+	fmt.Println("warning: " + msg)
+}
+
+// isDeclaredIn 标识符是否声明在节点内部, 例如局部变量和参数
+func isDeclaredIn(node ast.Node, obj *ast.Object) bool {
+	if obj == nil {
+		return false
+	}
+	decl, ok := obj.Decl.(ast.Node)
+	if !ok {
+		return false
+	}
+	return node.Pos() <= decl.Pos() && decl.End() <= node.End()
+}
+
+func findFuncDecl(fun *parse.Function) *ast.FuncDecl {
+	decl, _ := fun.Raw.(*ast.FuncDecl)
+	return decl
+}
+
+func modelTypeName(modelType *parse.ModelType) string {
+	switch n := modelType.Raw.(type) {
+	case *parse.StructType:
+		return n.Name
+	case *parse.InterfaceType:
+		return n.Name
+	case *parse.NamedType:
+		return n.Name
+	}
+	return ""
+}
+
+// resolveTypeValues 枚举类型的可选值, 即与类型关联的常量块中该类型的常量
+func resolveTypeValues(modelType *parse.ModelType) []*ValueMeta {
+	nt, ok := modelType.Raw.(*parse.NamedType)
+	if !ok || len(modelType.Consts) == 0 {
+		return nil
+	}
+	code := string(modelType.Content)
+	values := parse.EvalConstValues(code)
+	var result []*ValueMeta
+	for _, block := range modelType.Consts {
+		for _, c := range block.Consts {
+			if c.Type != nt.Name || c.Name == "_" {
+				continue
+			}
+			value, ok := values[c.Name]
+			if !ok {
+				value = c.Value
+			}
+			result = append(result, &ValueMeta{
+				Name:  c.Name,
+				Value: value,
+			})
+		}
+	}
+	return result
+}
@@ -0,0 +1,228 @@
+package emit
+
+import (
+	"errors"
+	"fmt"
+	"path"
+	"path/filepath"
+	"sort"
+	"sr/parse"
+	"sr/util"
+	"strings"
+
+	"github.com/gogf/gf/v2/os/gfile"
+)
+
+// contractModulePath 契约模块的模块路径, 契约模块位于提供方项目的子目录中, e.g. abc/contract
+func contractModulePath(module string, option Option) string {
+	return module + "/" + path.Clean(filepath.ToSlash(option.Contract))
+}
+
+// emitContract 生成独立的契约模块, 包含 slot 的调用接口和其中用到的类型, 调用方导入契约模块而不是复制类型
+func emitContract(root, module string, structs []*parse.StructType, option Option) error {
+	contract := path.Clean(filepath.ToSlash(option.Contract))
+	if path.IsAbs(contract) || contract == "." || strings.HasPrefix(contract, "../") || contract == ".." {
+		return errors.New("contract directory must be a subdirectory of the project, but got " + option.Contract)
+	}
+	dir := path.Join(root, contract)
+	modulePath := contractModulePath(module, option)
+	err := ensureDirExist(dir)
+	if err != nil {
+		return err
+	}
+	// 删除历史生成的接口, model.go 保留下来, 重新生成时沿用已经分配的类型名称
+	files, err := listFile(dir)
+	if err != nil {
+		return err
+	}
+	for _, f := range files {
+		if !util.StringEndOf(f, ".contract.go") {
+			continue
+		}
+		is, err := util.IsGenerateFile(f)
+		if err != nil {
+			return err
+		}
+		if is {
+			err = gfile.Remove(f)
+			if err != nil {
+				return err
+			}
+		}
+	}
+	var imports []string
+	for _, st := range structs {
+		helper := helperEmiter{
+			root:     root,
+			module:   module,
+			option:   option,
+			resolver: newTypeResolver(root, module, option),
+		}
+		ometa, err := helper.newSlotObjectMeta(st)
+		if err != nil {
+			return err
+		}
+		emiter := &helperInterfaceEmiter{
+			kind:      "contract",
+			root:      root,
+			target:    parse.GuessPackageName(modulePath),
+			ometa:     ometa,
+			module:    module,
+			writer:    util.NewTextWriter(),
+			toPackage: modulePath,
+			outDir:    dir,
+			exportTo:  map[string]string{},
+			origins:   map[string]string{},
+		}
+		err = emiter.emit()
+		if err != nil {
+			return err
+		}
+		for _, tmeta := range emiter.tmetas {
+			if tmeta.Import != nil {
+				imports = append(imports, tmeta.Import.Path)
+			}
+		}
+	}
+	return emitContractGoMod(root, dir, modulePath, imports)
+}
+
+// emitContractGoMod 生成契约模块的 go.mod, 依赖的版本与提供方保持一致
+func emitContractGoMod(root, dir, modulePath string, imports []string) error {
+	mod, err := readGoMod(path.Join(root, "go.mod"))
+	if err != nil {
+		return err
+	}
+	requires := map[string]bool{}
+	for _, pkgPath := range imports {
+		if module := mod.findModule(pkgPath); len(module) > 0 {
+			requires[module] = true
+		}
+	}
+	var modules []string
+	for module := range requires {
+		modules = append(modules, module)
+	}
+	sort.Strings(modules)
+	writer := util.NewTextWriter()
+	writer.WriteString(generatedHeader).WriteLine()
+	writer.WriteString("module ", modulePath).WriteLine()
+	if len(mod.goVersion) > 0 {
+		writer.WriteEmptyLine()
+		writer.WriteString("go ", mod.goVersion).WriteLine()
+	}
+	if len(modules) > 0 {
+		writer.WriteEmptyLine()
+		writer.WriteString("require (").WriteLine().IncreaseIndent()
+		for _, module := range modules {
+			writer.WriteString(module, " ", mod.requires[module]).WriteLine()
+		}
+		writer.DecreaseIndent().WriteString(")").WriteLine()
+	}
+	// 依赖的本地替换需要转换为相对于契约模块的路径
+	for _, r := range mod.replaces {
+		if !requires[r.old] {
+			continue
+		}
+		replace := r.old
+		if len(r.oldVersion) > 0 {
+			replace += " " + r.oldVersion
+		}
+		target := r.new
+		if r.isLocalReplace() && !filepath.IsAbs(r.new) {
+			rel, err := filepath.Rel(dir, filepath.Join(root, r.new))
+			if err != nil {
+				return err
+			}
+			target = filepath.ToSlash(rel)
+			if !strings.HasPrefix(target, ".") {
+				target = "./" + target
+			}
+		}
+		if len(r.newVersion) > 0 {
+			target += " " + r.newVersion
+		}
+		writer.WriteEmptyLine()
+		writer.WriteString("replace ", replace, " => ", target).WriteLine()
+	}
+	err = util.WriteGenerateFile(path.Join(dir, "go.mod"), writer.Bytes(), root)
+	if err != nil {
+		return err
+	}
+	// 依赖的校验和与提供方相同, 多余的条目不影响构建
+	if len(modules) > 0 && gfile.Exists(path.Join(root, "go.sum")) {
+		return gfile.CopyFile(path.Join(root, "go.sum"), path.Join(dir, "go.sum"))
+	}
+	return nil
+}
+
+// importContract 调用方通过 replace 在本地引用了契约模块时, 导入契约模块中的类型而不是复制
+func (e *helperInterfaceEmiter) importContract() error {
+	if len(e.ometa.Contract) == 0 || e.kind != "call" {
+		return nil
+	}
+	dir, err := findContractDir(e.root, e.ometa.Contract)
+	if err != nil || len(dir) == 0 {
+		return err
+	}
+	model, err := parse.ParseFileModel(path.Join(dir, "model.go"))
+	if err != nil {
+		return err
+	}
+	// 来源 => 契约模块中的名称
+	names := map[string]string{}
+	for name, tpe := range model.GetTypes() {
+		if origin := modelTypeOrigin(tpe); len(origin) > 0 {
+			names[origin] = name
+		}
+	}
+	// 契约模块落后于提供方时仍然复制类型
+	for _, tmeta := range e.tmetas {
+		if len(tmeta.Code) == 0 {
+			continue
+		}
+		if _, ok := names[tmeta.From+"."+tmeta.Name]; !ok {
+			printWarning(fmt.Sprintf("type %s.%s not found in contract module %s, copy types instead", tmeta.From, tmeta.Name, dir))
+			return nil
+		}
+	}
+	for _, tmeta := range e.tmetas {
+		if len(tmeta.Code) == 0 {
+			continue
+		}
+		tmeta.Name = names[tmeta.From+"."+tmeta.Name]
+		tmeta.Import = &ImportMeta{Path: e.ometa.Contract}
+		tmeta.Code = ""
+	}
+	return nil
+}
+
+// findContractDir 从调用方的 go.mod 中查找契约模块在本地的目录, 没有通过 replace 引用时返回空字符串
+func findContractDir(root, contract string) (string, error) {
+	mod, err := readGoMod(path.Join(root, "go.mod"))
+	if err != nil {
+		return "", err
+	}
+	for _, r := range mod.replaces {
+		if !r.isLocalReplace() || !isModulePackage(r.old, contract) {
+			continue
+		}
+		dir := filepath.FromSlash(r.new)
+		if !filepath.IsAbs(dir) {
+			dir = filepath.Join(root, dir)
+		}
+		dir = filepath.Join(dir, filepath.FromSlash(strings.TrimPrefix(contract, r.old)))
+		if gfile.Exists(filepath.Join(dir, "model.go")) {
+			return filepath.ToSlash(dir), nil
+		}
+	}
+	return "", nil
+}
+
+// packageDir 复制的类型所在包的目录
+func (e *helperInterfaceEmiter) packageDir(pkgPath string) string {
+	if pkgPath == e.toPackage {
+		return e.outDir
+	}
+	return packagePathToFileName(e.root, pkgPath)
+}
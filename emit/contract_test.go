@@ -0,0 +1,128 @@
+package emit
+
+import (
+	"os"
+	"path"
+	"sr/parse"
+	"strings"
+	"testing"
+
+	"github.com/gogf/gf/v2/os/gfile"
+)
+
+func TestReadGoMod(t *testing.T) {
+	filename := path.Join(t.TempDir(), "go.mod")
+	content := `module "abc" // comment
+
+go 1.18
+
+require example.com/a v1.0.0
+
+require (
+	example.com/b v1.2.0 // indirect
+	example.com/b/v2 v2.0.0
+)
+
+replace example.com/a => ../a
+
+replace (
+	example.com/b v1.2.0 => example.com/c v1.3.0
+)
+`
+	err := os.WriteFile(filename, []byte(content), 0644)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	mod, err := readGoMod(filename)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	if mod.module != "abc" || mod.goVersion != "1.18" || len(mod.requires) != 3 || mod.requires["example.com/b"] != "v1.2.0" {
+		t.Errorf("except module abc, go 1.18 and 3 requires, but got %s, %s and %d", mod.module, mod.goVersion, len(mod.requires))
+		return
+	}
+	if len(mod.replaces) != 2 || !mod.replaces[0].isLocalReplace() || mod.replaces[1].oldVersion != "v1.2.0" || mod.replaces[1].newVersion != "v1.3.0" {
+		t.Errorf("except 2 replaces, but got %d", len(mod.replaces))
+		return
+	}
+	if module := mod.findModule("example.com/b/v2/dto"); module != "example.com/b/v2" {
+		t.Errorf("except module example.com/b/v2 but got %s", module)
+	}
+}
+
+func TestEmitContract(t *testing.T) {
+	root := path.Join(t.TempDir(), "provider")
+	err := gfile.Copy("testdata/resolver", root)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	code := "package logic\n\nimport (\n\t\"abc/model6\"\n\t\"context\"\n)\n\ntype sOrder struct{}\n\n" +
+		"func (s *sOrder) Get(ctx context.Context, o *model6.Order) (*model6.Node, error) {\n\treturn nil, nil\n}\n"
+	err = gfile.PutContents(path.Join(root, "logic", "order.go"), code)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	file, err := parse.ParseFile(path.Join(root, "logic", "order.go"))
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	structs := parse.CombineStructTypes([]*parse.File{file})
+	option := Option{Contract: "contract"}
+	err = emitContract(root, "abc", structs, option)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	mod, err := readGoMod(path.Join(root, "contract", "go.mod"))
+	if err != nil || mod.module != "abc/contract" {
+		t.Errorf("except contract module abc/contract, but got %v", err)
+		return
+	}
+	contract := gfile.GetContents(path.Join(root, "contract", "order.contract.go"))
+	if !strings.Contains(contract, "package contract") || !strings.Contains(contract, "Get(ctx context.Context, o *Order) (*Node, error)") || strings.Contains(contract, "RegisterOrder") {
+		t.Errorf("except only interface IOrder in contract, but got %s", contract)
+		return
+	}
+	model := gfile.GetContents(path.Join(root, "contract", "model.go"))
+	for _, name := range []string{"Order", "Customer", "Node"} {
+		if !strings.Contains(model, "type "+name+" struct") {
+			t.Errorf("except type %s in contract, but got %s", name, model)
+			return
+		}
+	}
+	// 调用方通过 replace 引用契约模块时导入其中的类型
+	consumer := path.Join(path.Dir(root), "consumer")
+	err = gfile.PutContents(path.Join(consumer, "go.mod"), "module xyz\n\nrequire abc/contract v0.0.0\n\nreplace abc/contract => ../provider/contract\n")
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	helper := helperEmiter{root: root, module: "abc", option: option, resolver: newTypeResolver(root, "abc", option)}
+	ometa, err := helper.newObjectMeta("Order", "slot", "", structs[0].Functions)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	if ometa.Contract != "abc/contract" {
+		t.Errorf("except object meta contract abc/contract, but got %s", ometa.Contract)
+		return
+	}
+	err = EmitInterfaceFromHelper(consumer, "order", ometa, "call")
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	call := gfile.GetContents(path.Join(consumer, "internal", "srpc", "service", "order", "order.call.go"))
+	if !strings.Contains(call, `import "abc/contract"`) || !strings.Contains(call, "o *contract.Order) (*contract.Node, error)") {
+		t.Errorf("except types imported from contract, but got %s", call)
+		return
+	}
+	if gfile.Exists(path.Join(consumer, "internal", "srpc", "service", "order", "model.go")) {
+		t.Errorf("except no types copied")
+	}
+}
@@ -0,0 +1,155 @@
+package emit
+
+import (
+	"go/token"
+	"sr/parse"
+	"strings"
+)
+
+// 方法上可以使用的 //sr: 指令
+const (
+	// 不生成远程调用
+	directiveIgnore = "ignore"
+	// 修改远程调用的名称, e.g. //sr:name Create
+	directiveName = "name"
+	// 标记为已废弃, e.g. //sr:deprecated use Create instead
+	directiveDeprecated = "deprecated"
+	// 注册远程调用, 但不在 Helper.list 中公开
+	directiveInternal = "internal"
+	// 参数的 GoFrame 校验规则, 在调用方法前校验, e.g. //sr:valid count required|between:1,100#数量必须在 1 到 100 之间
+	directiveValid = "valid"
+	// 允许调用的服务名称, 多个名称用空格或逗号分隔, e.g. //sr:allow billing gateway
+	directiveAllow = "allow"
+	// 并发数量和速率限制, e.g. //sr:limit concurrency=4 rate=10 burst=20
+	directiveLimit = "limit"
+	// 执行时间上限, e.g. //sr:timeout 5s
+	directiveTimeout = "timeout"
+)
+
+// checkDirectives 校验方法上的指令, 同一个对象中的远程调用名称不能重复
+func checkDirectives(funs []*parse.Function, root string) error {
+	actions := map[string]*parse.Function{}
+	for _, fun := range funs {
+		for _, d := range fun.Directives {
+			switch d.Name {
+			case directiveIgnore, directiveInternal:
+				if len(d.Args) > 0 {
+					return formatError(fun.Parent.FileSet, d.Pos, "directive //sr:"+d.Name+" takes no arguments", root)
+				}
+			case directiveName:
+				if !token.IsIdentifier(d.Args) || !token.IsExported(d.Args) {
+					return formatError(fun.Parent.FileSet, d.Pos, "directive //sr:name requires an exported identifier, but got \""+d.Args+"\"", root)
+				}
+			case directiveDeprecated:
+				if len(d.Args) == 0 {
+					return formatError(fun.Parent.FileSet, d.Pos, "directive //sr:deprecated requires a message", root)
+				}
+			case directiveAllow:
+				if len(splitCallers(d.Args)) == 0 {
+					return formatError(fun.Parent.FileSet, d.Pos, "directive //sr:allow requires at least one caller name", root)
+				}
+			case directiveLimit:
+				if _, err := parseLimit(d.Args); err != nil {
+					return formatError(fun.Parent.FileSet, d.Pos, err.Error(), root)
+				}
+				if parse.FindDirective(fun.Directives, directiveLimit) != d {
+					return formatError(fun.Parent.FileSet, d.Pos, "directive //sr:limit already declared", root)
+				}
+			case directiveTimeout:
+				if _, err := parseTimeout(d.Args); err != nil {
+					return formatError(fun.Parent.FileSet, d.Pos, "directive //sr:"+err.Error(), root)
+				}
+				if parse.FindDirective(fun.Directives, directiveTimeout) != d {
+					return formatError(fun.Parent.FileSet, d.Pos, "directive //sr:timeout already declared", root)
+				}
+			case directiveValid:
+				err := checkValidDirective(fun, d, root)
+				if err != nil {
+					return err
+				}
+			default:
+				return formatError(fun.Parent.FileSet, d.Pos, "unknown directive //sr:"+d.Name, root)
+			}
+		}
+		if isIgnored(fun) {
+			continue
+		}
+		name := actionName(fun)
+		if other, ok := actions[name]; ok {
+			return formatError(fun.Parent.FileSet, fun.Pos, "action name "+name+" already used by method "+other.Name, root)
+		}
+		actions[name] = fun
+	}
+	return nil
+}
+
+// checkValidDirective 校验规则只能用于除 ctx 以外的参数, 每个参数只能有一条规则
+func checkValidDirective(fun *parse.Function, d *parse.Directive, root string) error {
+	name, rule := splitValidDirective(d)
+	if len(name) == 0 || len(rule) == 0 {
+		return formatError(fun.Parent.FileSet, d.Pos, "directive //sr:valid requires a parameter name and rules", root)
+	}
+	if strings.Contains(rule, "`") {
+		return formatError(fun.Parent.FileSet, d.Pos, "directive //sr:valid rules cannot contain a backquote", root)
+	}
+	found := false
+	for i, p := range fun.Params {
+		if i > 0 && p.Name == name {
+			found = true
+		}
+	}
+	if !found {
+		return formatError(fun.Parent.FileSet, d.Pos, "directive //sr:valid refers to unknown parameter "+name, root)
+	}
+	for _, other := range fun.Directives {
+		if other == d {
+			break
+		}
+		if n, _ := splitValidDirective(other); other.Name == directiveValid && n == name {
+			return formatError(fun.Parent.FileSet, d.Pos, "directive //sr:valid for parameter "+name+" already declared", root)
+		}
+	}
+	return nil
+}
+
+func splitValidDirective(d *parse.Directive) (name, rule string) {
+	name, rule, _ = strings.Cut(strings.TrimSpace(d.Args), " ")
+	return name, strings.TrimSpace(rule)
+}
+
+// validRule 参数的校验规则, 没有时为空
+func validRule(fun *parse.Function, param string) string {
+	for _, d := range fun.Directives {
+		if d.Name != directiveValid {
+			continue
+		}
+		if name, rule := splitValidDirective(d); name == param {
+			return rule
+		}
+	}
+	return ""
+}
+
+// actionName 远程调用的名称, 默认为方法名称
+func actionName(fun *parse.Function) string {
+	if d := parse.FindDirective(fun.Directives, directiveName); d != nil {
+		return d.Args
+	}
+	return fun.Name
+}
+
+func isIgnored(fun *parse.Function) bool {
+	return parse.FindDirective(fun.Directives, directiveIgnore) != nil
+}
+
+func isInternal(fun *parse.Function) bool {
+	return parse.FindDirective(fun.Directives, directiveInternal) != nil
+}
+
+// deprecatedMessage 废弃说明, 未废弃时为空
+func deprecatedMessage(fun *parse.Function) string {
+	if d := parse.FindDirective(fun.Directives, directiveDeprecated); d != nil {
+		return d.Args
+	}
+	return ""
+}
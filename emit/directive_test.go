@@ -0,0 +1,58 @@
+package emit
+
+import (
+	"sr/parse"
+	"strings"
+	"testing"
+)
+
+func TestCheckDirectives(t *testing.T) {
+	cases := []struct {
+		code string
+		err  string
+	}{
+		{"//sr:name Fetch\nfunc (s *sUser) Get() {}\nfunc (s *sUser) List() {}", ""},
+		{"//sr:ignore\nfunc (s *sUser) Get() {}\nfunc (s *sUser) Get2() {}", ""},
+		{"//sr:name List\nfunc (s *sUser) Get() {}\nfunc (s *sUser) List() {}", "action name List already used by method Get"},
+		{"//sr:name fetch\nfunc (s *sUser) Get() {}", "requires an exported identifier"},
+		{"//sr:deprecated\nfunc (s *sUser) Get() {}", "requires a message"},
+		{"//sr:ignore all\nfunc (s *sUser) Get() {}", "takes no arguments"},
+		{"//sr:hide\nfunc (s *sUser) Get() {}", "unknown directive //sr:hide"},
+		{"//sr:allow billing, gateway\nfunc (s *sUser) Get() {}", ""},
+		{"//sr:limit concurrency=4 rate=0.5\nfunc (s *sUser) Get() {}", ""},
+		{"//sr:limit concurrency=0\nfunc (s *sUser) Get() {}", "a positive number is required"},
+		{"//sr:limit rate=1\n//sr:limit concurrency=2\nfunc (s *sUser) Get() {}", "directive //sr:limit already declared"},
+		{"//sr:timeout 1m30s\nfunc (s *sUser) Get() {}", ""},
+		{"//sr:timeout 0s\nfunc (s *sUser) Get() {}", "timeout requires a positive duration"},
+		{"//sr:timeout 5s\n//sr:timeout 10s\nfunc (s *sUser) Get() {}", "directive //sr:timeout already declared"},
+		{"//sr:allow\nfunc (s *sUser) Get() {}", "requires at least one caller name"},
+		{"//sr:valid id required|min:1\nfunc (s *sUser) Get(ctx context.Context, id int) {}", ""},
+		{"//sr:valid id\nfunc (s *sUser) Get(ctx context.Context, id int) {}", "requires a parameter name and rules"},
+		{"//sr:valid ctx required\nfunc (s *sUser) Get(ctx context.Context, id int) {}", "unknown parameter ctx"},
+		{"//sr:valid id required\n//sr:valid id min:1\nfunc (s *sUser) Get(ctx context.Context, id int) {}", "parameter id already declared"},
+	}
+	for _, c := range cases {
+		f, err := parse.ParseContent("test.go", []byte("package p\n\n"+c.code))
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		err = checkDirectives(f.Functions, "")
+		if len(c.err) == 0 && err != nil {
+			t.Errorf("except no error but got %v", err)
+			return
+		}
+		if len(c.err) > 0 && (err == nil || !strings.Contains(err.Error(), c.err)) {
+			t.Errorf("except error %s but got %v", c.err, err)
+			return
+		}
+	}
+	f, _ := parse.ParseContent("test.go", []byte("package p\n\n//sr:valid id  required|min:1#请输入 id\nfunc (s *sUser) Get(ctx context.Context, id int) {}"))
+	if rule := validRule(f.Functions[0], "id"); rule != "required|min:1#请输入 id" {
+		t.Errorf("except rule required|min:1#请输入 id but got %s", rule)
+	}
+	f, _ = parse.ParseContent("test.go", []byte("package p\n\n//sr:name Fetch\nfunc (s *sUser) Get() {}"))
+	if actionName(f.Functions[0]) != "Fetch" {
+		t.Errorf("except action name Fetch but got %s", actionName(f.Functions[0]))
+	}
+}
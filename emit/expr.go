@@ -0,0 +1,164 @@
+package emit
+
+import (
+	"go/ast"
+	"go/token"
+	"go/types"
+	"sort"
+)
+
+// typeRef 类型表达式中对某个非内置类型的引用
+// e.g. map[model1.A][]*B => {scope: model1, name: A}, {name: B}
+type typeRef struct {
+	scope string
+	name  string
+	pos   token.Pos
+	end   token.Pos
+	expr  ast.Expr
+}
+
+// collectTypeRefs 遍历类型表达式的语法树, 收集其中所有非内置类型的引用
+func collectTypeRefs(expr ast.Expr) []typeRef {
+	var refs []typeRef
+	walkTypeExpr(expr, func(ref typeRef) {
+		refs = append(refs, ref)
+	})
+	return refs
+}
+
+func walkTypeExpr(expr ast.Expr, visit func(typeRef)) {
+	switch n := expr.(type) {
+	case *ast.Ident:
+		if isBuiltin(n.Name) {
+			return
+		}
+		visit(typeRef{name: n.Name, pos: n.Pos(), end: n.End(), expr: n})
+	case *ast.SelectorExpr:
+		if x, ok := n.X.(*ast.Ident); ok {
+			visit(typeRef{scope: x.Name, name: n.Sel.Name, pos: n.Pos(), end: n.End(), expr: n})
+		}
+	case *ast.StarExpr:
+		walkTypeExpr(n.X, visit)
+	case *ast.ParenExpr:
+		walkTypeExpr(n.X, visit)
+	case *ast.Ellipsis:
+		walkTypeExpr(n.Elt, visit)
+	case *ast.ArrayType:
+		// 数组长度不是类型, 不需要处理
+		walkTypeExpr(n.Elt, visit)
+	case *ast.MapType:
+		walkTypeExpr(n.Key, visit)
+		walkTypeExpr(n.Value, visit)
+	case *ast.ChanType:
+		walkTypeExpr(n.Value, visit)
+	case *ast.FuncType:
+		walkFieldList(n.Params, visit)
+		walkFieldList(n.Results, visit)
+	case *ast.StructType:
+		walkFieldList(n.Fields, visit)
+	case *ast.InterfaceType:
+		walkFieldList(n.Methods, visit)
+	case *ast.IndexExpr:
+		// 泛型实例化 Page[User]
+		walkTypeExpr(n.X, visit)
+		walkTypeExpr(n.Index, visit)
+	case *ast.IndexListExpr:
+		walkTypeExpr(n.X, visit)
+		for _, index := range n.Indices {
+			walkTypeExpr(index, visit)
+		}
+	case *ast.BinaryExpr:
+		// 类型约束 ~int | ~string
+		walkTypeExpr(n.X, visit)
+		walkTypeExpr(n.Y, visit)
+	case *ast.UnaryExpr:
+		walkTypeExpr(n.X, visit)
+	}
+}
+
+// collectDeclTypeRefs 收集类型声明中引用的类型, 包括类型参数的约束, 类型参数本身不是引用
+func collectDeclTypeRefs(typeParams *ast.FieldList, expr ast.Expr) []typeRef {
+	params := map[string]bool{}
+	var exprs []ast.Expr
+	if typeParams != nil {
+		for _, field := range typeParams.List {
+			for _, name := range field.Names {
+				params[name.Name] = true
+			}
+			exprs = append(exprs, field.Type)
+		}
+	}
+	exprs = append(exprs, expr)
+	var refs []typeRef
+	for _, e := range exprs {
+		walkTypeExpr(e, func(ref typeRef) {
+			if len(ref.scope) == 0 && params[ref.name] {
+				return
+			}
+			refs = append(refs, ref)
+		})
+	}
+	return refs
+}
+
+func walkFieldList(list *ast.FieldList, visit func(typeRef)) {
+	if list == nil {
+		return
+	}
+	for _, field := range list.List {
+		walkTypeExpr(field.Type, visit)
+	}
+}
+
+// rewriteTypeRefs 将 content 中 [pos, end) 范围的源码里的类型引用替换为 replace 的结果
+// content 为整个文件的内容, 位置与 token.Pos 一一对应
+func rewriteTypeRefs(content []byte, pos, end token.Pos, refs []typeRef, replace func(ref typeRef) (string, error)) (string, error) {
+	sorted := make([]typeRef, len(refs))
+	copy(sorted, refs)
+	sort.Slice(sorted, func(i, j int) bool {
+		return sorted[i].pos < sorted[j].pos
+	})
+	var result []byte
+	last := pos
+	for _, ref := range sorted {
+		// 同一个类型表达式可能被多个字段共享, 例如 A, B pkg.T
+		if ref.pos < last {
+			continue
+		}
+		s, err := replace(ref)
+		if err != nil {
+			return "", err
+		}
+		result = append(result, content[last-1:ref.pos-1]...)
+		result = append(result, s...)
+		last = ref.end
+	}
+	result = append(result, content[last-1:end-1]...)
+	return string(result), nil
+}
+
+func hasCustomType(expr ast.Expr) bool {
+	has := false
+	walkTypeExpr(expr, func(typeRef) {
+		has = true
+	})
+	return has
+}
+
+func fieldTypeExpr(v interface{}) ast.Expr {
+	if expr, ok := v.(ast.Expr); ok {
+		return expr
+	}
+	return nil
+}
+
+func typeParamList(v interface{}) *ast.FieldList {
+	list, _ := v.(*ast.FieldList)
+	return list
+}
+
+// isBuiltin 是否为预声明的类型, 例如 int, string, error, any
+func isBuiltin(name string) bool {
+	_, ok := types.Universe.Lookup(name).(*types.TypeName)
+	return ok
+}
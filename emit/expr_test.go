@@ -0,0 +1,71 @@
+package emit
+
+import (
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"testing"
+)
+
+func TestCollectTypeRefs(t *testing.T) {
+	excepts := map[string][]string{
+		"[]*pkg.T":                              {"pkg.T"},
+		"map[pkg.K]pkg.V":                       {"pkg.K", "pkg.V"},
+		"func(ctx context.Context) error":       {"context.Context"},
+		"[4]item":                               {"item"},
+		"struct{ User User; Age int }":          {"User"},
+		"chan<- map[string]interface{}":         nil,
+		"func(a ...pkg.T) (r1 *local, e error)": {"pkg.T", "local"},
+		"*pkg.Page[pkg.User]":                   {"pkg.Page", "pkg.User"},
+		"Pair[string, []Item]":                  {"Pair", "Item"},
+	}
+	for code, except := range excepts {
+		expr, err := parser.ParseExpr(code)
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		refs := collectTypeRefs(expr)
+		if len(refs) != len(except) {
+			t.Errorf("except %s type refs count = %d, but got %d", code, len(except), len(refs))
+			return
+		}
+		for i, ref := range refs {
+			name := ref.name
+			if len(ref.scope) > 0 {
+				name = ref.scope + "." + ref.name
+			}
+			if name != except[i] {
+				t.Errorf("except %s type refs[%d] = %s, but got %s", code, i, except[i], name)
+				return
+			}
+		}
+	}
+}
+
+func TestCollectDeclTypeRefs(t *testing.T) {
+	f, err := parser.ParseFile(token.NewFileSet(), "test.go", `package p
+type Stats[N Number, K comparable] struct {
+	Sum   N
+	Pairs []Pair[K, N]
+	Owner *model.User
+}`, 0)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	spec := f.Decls[0].(*ast.GenDecl).Specs[0].(*ast.TypeSpec)
+	refs := collectDeclTypeRefs(spec.TypeParams, spec.Type)
+	// 类型参数 N 和 K 不是引用
+	except := []string{"Number", "Pair", "User"}
+	if len(refs) != len(except) {
+		t.Errorf("except type refs count = %d, but got %d", len(except), len(refs))
+		return
+	}
+	for i, ref := range refs {
+		if ref.name != except[i] {
+			t.Errorf("except type refs[%d] = %s, but got %s", i, except[i], ref.name)
+			return
+		}
+	}
+}
@@ -0,0 +1,76 @@
+package emit
+
+import (
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+
+	"golang.org/x/mod/modfile"
+)
+
+// goMod go.mod 中生成契约模块需要的内容
+type goMod struct {
+	module    string
+	goVersion string
+	// 模块路径 => 版本
+	requires map[string]string
+	replaces []*goModReplace
+}
+
+type goModReplace struct {
+	old        string
+	oldVersion string
+	new        string
+	newVersion string
+}
+
+// readGoMod 读取 go.mod 中的 module, go, require 和 replace
+func readGoMod(filename string) (*goMod, error) {
+	data, err := ioutil.ReadFile(filename)
+	if err != nil {
+		return nil, err
+	}
+	f, err := modfile.Parse(filename, data, nil)
+	if err != nil {
+		return nil, err
+	}
+	mod := &goMod{requires: map[string]string{}}
+	if f.Module != nil {
+		mod.module = f.Module.Mod.Path
+	}
+	if f.Go != nil {
+		mod.goVersion = f.Go.Version
+	}
+	for _, r := range f.Require {
+		mod.requires[r.Mod.Path] = r.Mod.Version
+	}
+	for _, r := range f.Replace {
+		mod.replaces = append(mod.replaces, &goModReplace{
+			old:        r.Old.Path,
+			oldVersion: r.Old.Version,
+			new:        r.New.Path,
+			newVersion: r.New.Version,
+		})
+	}
+	return mod, nil
+}
+
+// findModule 导入路径所属的模块, 依赖中没有时返回空字符串
+func (m *goMod) findModule(pkgPath string) string {
+	result := ""
+	for module := range m.requires {
+		if isModulePackage(module, pkgPath) && len(module) > len(result) {
+			result = module
+		}
+	}
+	return result
+}
+
+// isLocalReplace replace 的目标是本地目录
+func (r *goModReplace) isLocalReplace() bool {
+	return strings.HasPrefix(r.new, "./") || strings.HasPrefix(r.new, "../") || r.new == "." || r.new == ".." || filepath.IsAbs(r.new)
+}
+
+func isModulePackage(module, pkgPath string) bool {
+	return pkgPath == module || strings.HasPrefix(pkgPath, module+"/")
+}
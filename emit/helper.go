@@ -2,38 +2,43 @@ package emit
 
 import (
 	"fmt"
-	"go/token"
+	"go/ast"
 	"os"
 	"path"
 	"regexp"
 	"sr/parse"
 	"sr/util"
+	"strconv"
 	"strings"
 
-	"github.com/aundis/meta"
 	"github.com/gogf/gf/v2/os/gfile"
 )
 
-func emitSlotHelper(root, module string, writer util.TextWriter, st *parse.StructType) error {
+func emitSlotHelper(root, module string, writer util.TextWriter, st *parse.StructType, option Option) error {
 	emiter := helperEmiter{
-		root:   root,
-		module: module,
-		writer: writer,
+		root:     root,
+		module:   module,
+		option:   option,
+		writer:   writer,
+		resolver: newTypeResolver(root, module, option),
 	}
-	err := emiter.emitHelperRest(st.Parent, st.Name[1:], "slot", st.Functions)
+	ometa, err := emiter.newSlotObjectMeta(st)
 	if err != nil {
 		return err
 	}
+	emiter.emitObjectMeta(ometa)
 	return nil
 }
 
-func emitSignalHelper(root, module string, writer util.TextWriter, it *parse.InterfaceType) error {
+func emitSignalHelper(root, module string, writer util.TextWriter, it *parse.InterfaceType, option Option) error {
 	emiter := helperEmiter{
-		root:   root,
-		module: module,
-		writer: writer,
+		root:     root,
+		module:   module,
+		option:   option,
+		writer:   writer,
+		resolver: newTypeResolver(root, module, option),
 	}
-	err := emiter.emitHelperRest(it.Parent, it.Name[1:], "signal", it.Functions)
+	err := emiter.emitHelperRest(it.Parent, it.Name[1:], "signal", it.Doc, it.Functions)
 	if err != nil {
 		return err
 	}
@@ -43,82 +48,162 @@ func emitSignalHelper(root, module string, writer util.TextWriter, it *parse.Int
 type helperEmiter struct {
 	root   string
 	module string
+	option Option
 	writer util.TextWriter
+	// 同一个对象的所有字段共用, 同一个类型在元数据中只有一个 id
+	resolver *typeResolver
 }
 
-func (e *helperEmiter) emitHelperRest(file *parse.File, name, kind string, funcs []*parse.Function) error {
-	writer := e.writer
-	writer.WriteString("manager.AddObjectMetaHelper(meta.ObjectMeta{").WriteLine().IncreaseIndent()
-	writer.WriteString(`Name: "`, name, `",`).WriteLine()
-	writer.WriteString(`Kind: "`, kind, `",`).WriteLine()
-	writer.WriteString(`Functions: []*meta.FunctionMeta{`).WriteLine().IncreaseIndent()
+func (e *helperEmiter) emitHelperRest(file *parse.File, name, kind, doc string, funcs []*parse.Function) error {
+	ometa, err := e.newObjectMeta(name, kind, doc, funcs)
+	if err != nil {
+		return err
+	}
+	e.emitObjectMeta(ometa)
+	return nil
+}
+
+// newSlotObjectMeta 结构体的元数据, meta.Slot 标签中的 description 优先于文档注释
+func (e *helperEmiter) newSlotObjectMeta(st *parse.StructType) (*ObjectMeta, error) {
+	tag := slotTag(st)
+	doc := st.Doc
+	if description := tag.Get("description"); len(description) > 0 {
+		doc = description
+	}
+	ometa, err := e.newObjectMeta(slotObjectName(st), "slot", doc, st.Functions)
+	if err != nil {
+		return nil, err
+	}
+	ometa.Allow = slotAllowedCallers(st)
+	ometa.Version = tag.Get("version")
+	ometa.Owner = tag.Get("owner")
+	return ometa, nil
+}
+
+// newObjectMeta 生成对象的元数据, 内部方法不在 Helper.list 中公开
+func (e *helperEmiter) newObjectMeta(name, kind, doc string, funcs []*parse.Function) (*ObjectMeta, error) {
+	ometa := &ObjectMeta{
+		Name: name,
+		Kind: kind,
+		Doc:  doc,
+	}
+	if kind == "slot" && len(e.option.Contract) > 0 {
+		ometa.Contract = contractModulePath(e.module, e.option)
+	}
 	for _, f := range funcs {
+		if isIgnored(f) || isInternal(f) {
+			continue
+		}
+		fmeta := &FunctionMeta{
+			Name:       actionName(f),
+			Doc:        f.Doc,
+			Deprecated: deprecatedMessage(f),
+			Allow:      allowedCallers(f),
+		}
+		var err error
+		fmeta.Parameters, err = e.newFieldMetas(f, f.Params)
+		if err != nil {
+			return nil, err
+		}
+		fmeta.Results, err = e.newFieldMetas(f, f.Results)
+		if err != nil {
+			return nil, err
+		}
+		ometa.Functions = append(ometa.Functions, fmeta)
+	}
+	return ometa, nil
+}
+
+func (e *helperEmiter) newFieldMetas(f *parse.Function, fields []*parse.Field) ([]*FieldMeta, error) {
+	var result []*FieldMeta
+	for _, field := range fields {
+		fmeta := &FieldMeta{
+			Name: field.Name,
+			Type: field.Type,
+		}
+		if expr := fieldTypeExpr(field.TypeRaw); expr != nil && hasCustomType(expr) {
+			template, typeMetas, err := e.resolveTypeMetas(f.Parent, expr)
+			if err != nil {
+				return nil, err
+			}
+			fmeta.Type, fmeta.TypeMetas = template, typeMetas
+		}
+		result = append(result, fmeta)
+	}
+	return result, nil
+}
+
+func (e *helperEmiter) emitObjectMeta(ometa *ObjectMeta) {
+	writer := e.writer
+	writer.WriteString("manager.AddObjectMetaHelper(manager.ObjectMeta{").WriteLine().IncreaseIndent()
+	writer.WriteString(`Name: "`, ometa.Name, `",`).WriteLine()
+	writer.WriteString(`Kind: "`, ometa.Kind, `",`).WriteLine()
+	if len(ometa.Doc) > 0 {
+		writer.WriteString(`Doc: "`, formatToCodeString(ometa.Doc), `",`).WriteLine()
+	}
+	if len(ometa.Contract) > 0 {
+		writer.WriteString(`Contract: "`, ometa.Contract, `",`).WriteLine()
+	}
+	if len(ometa.Allow) > 0 {
+		writer.WriteString(`Allow: `, stringSliceCode(ometa.Allow), `,`).WriteLine()
+	}
+	if len(ometa.Version) > 0 {
+		writer.WriteString(`Version: "`, formatToCodeString(ometa.Version), `",`).WriteLine()
+	}
+	if len(ometa.Owner) > 0 {
+		writer.WriteString(`Owner: "`, formatToCodeString(ometa.Owner), `",`).WriteLine()
+	}
+	writer.WriteString(`Functions: []*manager.FunctionMeta{`).WriteLine().IncreaseIndent()
+	for _, f := range ometa.Functions {
 		writer.WriteString("{").WriteLine().IncreaseIndent()
 		writer.WriteString(`Name: `, `"`, f.Name, `",`).WriteLine()
-		if len(f.Params) > 0 {
-			writer.WriteString(`Parameters: []*meta.FieldMeta{`).WriteLine().IncreaseIndent()
-			for _, p := range f.Params {
-				writer.WriteString("{").WriteLine().IncreaseIndent()
-				writer.WriteString(`Name: `, `"`, p.Name, `",`).WriteLine()
-				if hasCustomerType(p.Type) {
-					template, typeMetas, err := e.resolveTypeMetas(f.Parent, p.Type, p.Pos)
-					if err != nil {
-						return err
-					}
-					writer.WriteString(`Type : `, `"`, template, `",`).WriteLine()
-					e.emitTypeMetas(typeMetas)
-				} else {
-					writer.WriteString(`Type : `, `"`, p.Type, `",`).WriteLine()
-				}
-				writer.DecreaseIndent().WriteString("},").WriteLine()
-			}
-			writer.DecreaseIndent().WriteString("},").WriteLine()
+		if len(f.Doc) > 0 {
+			writer.WriteString(`Doc: "`, formatToCodeString(f.Doc), `",`).WriteLine()
 		}
-		if len(f.Results) > 0 {
-			writer.WriteString(`Results: []*meta.FieldMeta{`).WriteLine().IncreaseIndent()
-			for _, r := range f.Results {
-				writer.WriteString("{").WriteLine().IncreaseIndent()
-				writer.WriteString(`Name: `, `"`, r.Name, `",`).WriteLine()
-				if hasCustomerType(r.Type) {
-					template, typeMetas, err := e.resolveTypeMetas(f.Parent, r.Type, r.Pos)
-					if err != nil {
-						return err
-					}
-					writer.WriteString(`Type : `, `"`, template, `",`).WriteLine()
-					e.emitTypeMetas(typeMetas)
-				} else {
-					writer.WriteString(`Type : `, `"`, r.Type, `",`).WriteLine()
-				}
-				writer.DecreaseIndent().WriteString("},").WriteLine()
-			}
-			writer.DecreaseIndent().WriteString("},").WriteLine()
+		if len(f.Deprecated) > 0 {
+			writer.WriteString(`Deprecated: "`, formatToCodeString(f.Deprecated), `",`).WriteLine()
 		}
+		if len(f.Allow) > 0 {
+			writer.WriteString(`Allow: `, stringSliceCode(f.Allow), `,`).WriteLine()
+		}
+		e.emitFieldMetas("Parameters", f.Parameters)
+		e.emitFieldMetas("Results", f.Results)
 		writer.DecreaseIndent().WriteString("},").WriteLine()
 	}
 	writer.DecreaseIndent().WriteString("},").WriteLine()
 	writer.DecreaseIndent().WriteString("})").WriteLine()
-	return nil
 }
 
-func (e *helperEmiter) resolveTypeMetas(file *parse.File, compound string, pos token.Pos) (string, []*meta.TypeMeta, error) {
-	resolver := &typeResolver{
-		module:   e.module,
-		root:     e.root,
-		resolved: map[string]*meta.TypeMeta{},
+func (e *helperEmiter) emitFieldMetas(name string, fmetas []*FieldMeta) {
+	if len(fmetas) == 0 {
+		return
+	}
+	writer := e.writer
+	writer.WriteString(name, `: []*manager.FieldMeta{`).WriteLine().IncreaseIndent()
+	for _, fmeta := range fmetas {
+		writer.WriteString("{").WriteLine().IncreaseIndent()
+		writer.WriteString(`Name: `, `"`, fmeta.Name, `",`).WriteLine()
+		writer.WriteString(`Type : `, `"`, fmeta.Type, `",`).WriteLine()
+		e.emitTypeMetas(fmeta.TypeMetas)
+		writer.DecreaseIndent().WriteString("},").WriteLine()
 	}
-	template, err := resolver.resolve(file, compound, pos)
+	writer.DecreaseIndent().WriteString("},").WriteLine()
+}
+
+func (e *helperEmiter) resolveTypeMetas(file *parse.File, expr ast.Expr) (string, []*TypeMeta, error) {
+	template, err := e.resolver.resolve(file, expr)
 	if err != nil {
 		return "", nil, err
 	}
-	return template, resolver.getTypeMetas(), nil
+	return template, e.resolver.typeMetasOf(template), nil
 }
 
-func (e *helperEmiter) emitTypeMetas(typeMetas []*meta.TypeMeta) error {
+func (e *helperEmiter) emitTypeMetas(typeMetas []*TypeMeta) error {
 	if len(typeMetas) == 0 {
 		return nil
 	}
 	writer := e.writer
-	writer.WriteString(`TypeMetas: []*meta.TypeMeta{`).WriteLine().IncreaseIndent()
+	writer.WriteString(`TypeMetas: []*manager.TypeMeta{`).WriteLine().IncreaseIndent()
 	for _, t := range typeMetas {
 		writer.WriteString("{").WriteLine().IncreaseIndent()
 		writer.WriteString(`Id: "`, t.Id, `",`).WriteLine()
@@ -126,11 +211,18 @@ func (e *helperEmiter) emitTypeMetas(typeMetas []*meta.TypeMeta) error {
 		writer.WriteString(`From: "`, t.From, `",`).WriteLine()
 		writer.WriteString(`Code: "`, formatToCodeString(t.Code), `",`).WriteLine()
 		if t.Import != nil {
-			writer.WriteString(`Import: &meta.ImportMeta{`).WriteLine().IncreaseIndent()
+			writer.WriteString(`Import: &manager.ImportMeta{`).WriteLine().IncreaseIndent()
 			writer.WriteString(`Path: "`, t.Import.Path, `",`).WriteLine()
 			writer.WriteString(`Alias: "`, t.Import.Alias, `",`).WriteLine()
 			writer.DecreaseIndent().WriteString("},").WriteLine()
 		}
+		if len(t.Values) > 0 {
+			writer.WriteString(`Values: []*manager.ValueMeta{`).WriteLine().IncreaseIndent()
+			for _, v := range t.Values {
+				writer.WriteString(`{Name: "`, v.Name, `", Value: `, strconv.Quote(v.Value), `},`).WriteLine()
+			}
+			writer.DecreaseIndent().WriteString("},").WriteLine()
+		}
 		writer.DecreaseIndent().WriteString("},").WriteLine()
 	}
 	writer.DecreaseIndent().WriteString("},").WriteLine()
@@ -142,7 +234,7 @@ func (e *helperEmiter) convertPackagePathToLocalPath(pkgPath string) string {
 	return path.Join(e.root, strings.Join(part[1:], "/"))
 }
 
-func EmitInterfaceFromHelper(root string, target string, ometa *meta.ObjectMeta, kind string) error {
+func EmitInterfaceFromHelper(root string, target string, ometa *ObjectMeta, kind string) error {
 	module, err := getProjectModuleName(root)
 	if err != nil {
 		return err
@@ -155,7 +247,9 @@ func EmitInterfaceFromHelper(root string, target string, ometa *meta.ObjectMeta,
 		module:    module,
 		writer:    util.NewTextWriter(),
 		toPackage: fmt.Sprintf("%s/internal/srpc/service/%s", module, target),
+		outDir:    path.Join(root, "internal", "srpc", "service", target),
 		exportTo:  map[string]string{},
+		origins:   map[string]string{},
 	}
 	err = emiter.emit()
 	if err != nil {
@@ -168,19 +262,34 @@ type helperInterfaceEmiter struct {
 	kind      string
 	root      string
 	target    string
-	ometa     *meta.ObjectMeta
+	ometa     *ObjectMeta
 	module    string
 	writer    util.TextWriter
 	toPackage string
-	exportTo  map[string]string
-	fmetas    []*meta.FieldMeta
-	tmetas    []*meta.TypeMeta
+	// toPackage 对应的目录
+	outDir   string
+	exportTo map[string]string
+	imports  *importCollect
+	// 复制的类型的来源, 类型被重命名后用于还原代码中的名称
+	origins map[string]string
+	// 导出包@类型来源 => 类型携带的常量 => 分配的名称
+	constNames map[string]map[string]string
+	fmetas     []*FieldMeta
+	tmetas     []*TypeMeta
 }
 
 func (e *helperInterfaceEmiter) emit() error {
 	e.initMetas()
+	err := e.importContract()
+	if err != nil {
+		return err
+	}
 	e.redirectTypePackage()
-	err := e.emitHeader()
+	err = e.allocateTypeNames()
+	if err != nil {
+		return err
+	}
+	err = e.emitHeader()
 	if err != nil {
 		return err
 	}
@@ -193,12 +302,11 @@ func (e *helperInterfaceEmiter) emit() error {
 		return err
 	}
 
-	outDir := path.Join(e.root, "internal", "srpc", "service", e.target)
-	err = ensureDirExist(outDir)
+	err = ensureDirExist(e.outDir)
 	if err != nil {
 		return err
 	}
-	outPath := path.Join(e.root, "internal", "srpc", "service", e.target, toSnakeCase(e.ometa.Name)+"."+e.kind+".go")
+	outPath := path.Join(e.outDir, toSnakeCase(e.ometa.Name)+"."+e.kind+".go")
 	err = util.WriteGenerateFile(outPath, e.writer.Bytes(), e.root)
 	if err != nil {
 		return err
@@ -211,16 +319,25 @@ func (e *helperInterfaceEmiter) initMetas() {
 		e.fmetas = append(e.fmetas, f.Parameters...)
 		e.fmetas = append(e.fmetas, f.Results...)
 	}
+	// 不同字段引用的同一个类型只保留一份
+	seen := map[string]bool{}
 	for _, fmeta := range e.fmetas {
-		e.tmetas = append(e.tmetas, fmeta.TypeMetas...)
+		for _, tmeta := range fmeta.TypeMetas {
+			if seen[tmeta.Id] {
+				continue
+			}
+			seen[tmeta.Id] = true
+			e.tmetas = append(e.tmetas, tmeta)
+		}
 	}
 }
 
 func (e *helperInterfaceEmiter) redirectTypePackage() {
-	modelPackage := fmt.Sprintf("%s/internal/srpc/service/%s", e.module, e.target)
+	modelPackage := e.toPackage
 	for _, fmeta := range e.fmetas {
 		for _, tmeta := range fmeta.TypeMetas {
-			if isFromOtherService(tmeta.From) {
+			// 契约模块中的类型都在同一个包中
+			if isFromOtherService(tmeta.From) && e.kind != "contract" {
 				e.exportTo[tmeta.Id] = fmt.Sprintf("%s/internal/srpc/service/%s", e.module, getImportPathExport(tmeta.From))
 			} else {
 				e.exportTo[tmeta.Id] = modelPackage
@@ -246,74 +363,95 @@ func (e *helperInterfaceEmiter) emitHeader() error {
 }
 
 func (e *helperInterfaceEmiter) emitImports() error {
-	collect := newImportCollect()
-	var fmetas []*meta.FieldMeta
+	collect := newImportCollect(e.root)
+	var fmetas []*FieldMeta
 	for _, f := range e.ometa.Functions {
 		fmetas = append(fmetas, f.Parameters...)
 		fmetas = append(fmetas, f.Results...)
 	}
-	currentPackage := fmt.Sprintf("%s/internal/srpc/service/%s", e.module, e.target)
+	currentPackage := e.toPackage
 	for _, fmeta := range fmetas {
-		for _, tmeta := range fmeta.TypeMetas {
+		// 只导入签名中直接用到的类型, 类型代码内部的引用由 model.go 导入
+		for _, id := range findAllTypeMetaIds(fmeta.Type) {
+			tmeta := findTypeMetaForId(fmeta.TypeMetas, id)
+			if tmeta == nil {
+				continue
+			}
 			impo := tmeta.Import
 			if impo != nil {
-				collect.Set(getImportMetaExport(impo), impo.Path)
+				collect.Add(impo.Path)
 			}
 			if len(tmeta.Code) > 0 {
 				// 不是同一个包的代码片段需要import
 				to := e.exportTo[tmeta.Id]
 				if to != currentPackage {
-					collect.Set(getImportPathExport(to), to)
+					collect.Add(to)
 				}
 			}
 		}
 	}
 	e.writer.WriteEmptyLine()
 	collect.Emit(e.writer)
+	e.imports = collect
 	return nil
 }
 
 func (e *helperInterfaceEmiter) emitTypeMetas() error {
-	// 更改model
-	models := map[*parse.Model]bool{}
-	serviceDir := path.Join(e.root, "internal", "srpc", "service", e.target)
-	if !gfile.Exists(serviceDir) {
-		err := os.MkdirAll(serviceDir, os.ModePerm)
+	// 更改model, 每个 model.go 单独分配导入包的名称
+	models := map[*parse.Model]*importCollect{}
+	if !gfile.Exists(e.outDir) {
+		err := os.MkdirAll(e.outDir, os.ModePerm)
 		if err != nil {
 			return err
 		}
 	}
+	// 旧版本的元数据中同一个类型在不同字段里有不同的 id, 按来源只写入一次
+	written := map[string]bool{}
 	for _, tmeta := range e.tmetas {
 		if len(tmeta.Code) == 0 {
 			continue
 		}
 		modelPackage := e.exportTo[tmeta.Id]
-		filename := packagePathToFileName(e.root, modelPackage)
-		modelFileName := path.Join(filename, "model.go")
+		if written[modelPackage+"@"+e.origins[tmeta.Id]] {
+			continue
+		}
+		written[modelPackage+"@"+e.origins[tmeta.Id]] = true
+		modelFileName := path.Join(e.packageDir(modelPackage), "model.go")
 		model, err := parse.ParseFileModel(modelFileName)
 		if err != nil {
 			return err
 		}
-		models[model] = true
-		// 添加类型的import
-		for _, id := range findAllTypeMetaIds(tmeta.Code) {
-			cur := findTypeMetaForId(e.tmetas, id)
-			if cur.Import != nil {
-				model.AddImport(getImportMetaExport(cur.Import), cur.Import.Path)
-			}
-			if e.exportTo[id] != modelPackage {
-				model.AddImport(getImportPathExport(e.exportTo[id]), e.exportTo[id])
+		collect := models[model]
+		if collect == nil {
+			// 沿用已有类型使用的名称
+			collect = newImportCollect(e.root)
+			for name, path := range model.GetImports() {
+				collect.Set(name, path)
 			}
+			models[model] = collect
+		}
+		// 写入类型的代码, 重命名的类型需要修改声明中的名称
+		code := tmeta.Code
+		origin := e.origins[tmeta.Id]
+		if name := originName(origin); name != tmeta.Name {
+			code = renameTypeIdent(code, name, tmeta.Name)
 		}
-		// 写入类型的代码
+		if names := e.constNames[modelPackage+"@"+origin]; len(names) > 0 {
+			code = renameConstIdents(code, names)
+		}
+		code = e.replacePseudocodePart(modelPackage, code, collect)
+		code = insertDirective(code, &parse.Directive{Name: directiveFrom, Args: origin})
 		model.AddType(tmeta.Name, &parse.ModelType{
 			Raw:     nil,
-			Content: []byte(e.replacePseudocodePart(modelPackage, tmeta.Code)),
+			Content: []byte(code),
 		})
 
 	}
 	// 写出 models
-	for model := range models {
+	for model, collect := range models {
+		for name, path := range collect.Imports() {
+			model.AddImport(name, path)
+		}
 		filename := model.GetFileName()
 		err := emitModel(model, filename, e.root)
 		if err != nil {
@@ -327,14 +465,20 @@ func (e *helperInterfaceEmiter) emitBody() error {
 	ometa := e.ometa
 	writer := e.writer
 	writer.WriteEmptyLine()
+	e.emitDoc(ometa.Doc)
 	writer.WriteString("type I", ometa.Name, " interface {").WriteLine().IncreaseIndent()
 	for _, fmeta := range ometa.Functions {
+		e.emitDoc(functionDoc(fmeta))
 		err := e.emitFunction(fmeta)
 		if err != nil {
 			return err
 		}
 	}
 	writer.DecreaseIndent().WriteString("}").WriteLine()
+	// 契约模块只包含接口, 由调用方注册实现
+	if e.kind == "contract" {
+		return nil
+	}
 
 	// var localMath IMath
 	// func Math() IMath {
@@ -362,7 +506,28 @@ func (e *helperInterfaceEmiter) emitBody() error {
 	return nil
 }
 
-func (e *helperInterfaceEmiter) emitFunction(fmeta *meta.FunctionMeta) error {
+// emitDoc 输出文档注释
+func (e *helperInterfaceEmiter) emitDoc(doc string) {
+	if len(doc) == 0 {
+		return
+	}
+	for _, line := range strings.Split(strings.TrimSuffix(parse.DocComment(doc), "\n"), "\n") {
+		e.writer.WriteString(line).WriteLine()
+	}
+}
+
+// functionDoc 函数的文档注释, 废弃说明按 Go 的惯例作为最后一段
+func functionDoc(fmeta *FunctionMeta) string {
+	if len(fmeta.Deprecated) == 0 {
+		return fmeta.Doc
+	}
+	if len(fmeta.Doc) == 0 {
+		return "Deprecated: " + fmeta.Deprecated
+	}
+	return fmeta.Doc + "\n\nDeprecated: " + fmeta.Deprecated
+}
+
+func (e *helperInterfaceEmiter) emitFunction(fmeta *FunctionMeta) error {
 	writer := e.writer
 	if e.kind == "listen" {
 		writer.WriteString("On", fmeta.Name, "(fun func(")
@@ -373,7 +538,7 @@ func (e *helperInterfaceEmiter) emitFunction(fmeta *meta.FunctionMeta) error {
 		if i != 0 {
 			writer.WriteString(", ")
 		}
-		writer.WriteString(p.Name, " ", e.replacePseudocodePart(e.toPackage, p.Type))
+		writer.WriteString(p.Name, " ", e.replacePseudocodePart(e.toPackage, p.Type, e.imports))
 	}
 	writer.WriteString(") ")
 	if len(fmeta.Results) > 0 {
@@ -386,7 +551,7 @@ func (e *helperInterfaceEmiter) emitFunction(fmeta *meta.FunctionMeta) error {
 		if len(r.Name) > 0 {
 			writer.WriteString(r.Name, " ")
 		}
-		writer.WriteString(e.replacePseudocodePart(e.toPackage, r.Type))
+		writer.WriteString(e.replacePseudocodePart(e.toPackage, r.Type, e.imports))
 	}
 	if len(fmeta.Results) > 0 {
 		writer.WriteString(")")
@@ -398,7 +563,7 @@ func (e *helperInterfaceEmiter) emitFunction(fmeta *meta.FunctionMeta) error {
 	return nil
 }
 
-func (e *helperInterfaceEmiter) replacePseudocodePart(pkg string, content string) string {
+func (e *helperInterfaceEmiter) replacePseudocodePart(pkg string, content string, imports *importCollect) string {
 	return replacePseudocodePart(replacePseudocodePartInput{
 		content: content,
 		tmetas:  e.tmetas,
@@ -406,5 +571,6 @@ func (e *helperInterfaceEmiter) replacePseudocodePart(pkg string, content string
 			return e.exportTo[tmetaId]
 		},
 		currentPackage: pkg,
+		imports:        imports,
 	})
 }
@@ -0,0 +1,90 @@
+package emit
+
+import (
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"os"
+	"path"
+	"sr/parse"
+	"strings"
+	"testing"
+)
+
+func TestEmitInterfaceRecursiveTypes(t *testing.T) {
+	file, err := parse.ParseFile(`testdata/resolver/model6/model.go`)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	var order *parse.StructType
+	for _, st := range file.StructTypes {
+		if st.Name == "Order" {
+			order = st
+		}
+	}
+	fieldMeta := func(resolver *typeResolver, name string, field *parse.Field) *FieldMeta {
+		template, err := resolver.resolve(file, fieldTypeExpr(field.TypeRaw))
+		if err != nil {
+			t.Fatal(err)
+		}
+		return &FieldMeta{Name: name, Type: template, TypeMetas: resolver.typeMetasOf(template)}
+	}
+	// 共用解析器的元数据, 以及旧版本每个字段单独解析的元数据
+	shared := newTypeResolver(`testdata/resolver`, "abc", Option{})
+	legacy := func() *typeResolver {
+		return newTypeResolver(`testdata/resolver`, "abc", Option{})
+	}
+	ometas := []*ObjectMeta{
+		{Name: "Order", Kind: "slot", Functions: []*FunctionMeta{
+			{Name: "Get", Parameters: []*FieldMeta{fieldMeta(shared, "c", order.Fields[1])}, Results: []*FieldMeta{fieldMeta(shared, "", order.Fields[2])}},
+			{Name: "Tree", Results: []*FieldMeta{fieldMeta(shared, "", order.Fields[2])}},
+		}},
+		{Name: "Order", Kind: "slot", Functions: []*FunctionMeta{
+			{Name: "Get", Parameters: []*FieldMeta{fieldMeta(legacy(), "c", order.Fields[1])}, Results: []*FieldMeta{fieldMeta(legacy(), "", order.Fields[2])}},
+			{Name: "Tree", Results: []*FieldMeta{fieldMeta(legacy(), "", order.Fields[2])}},
+		}},
+	}
+	for _, ometa := range ometas {
+		root := t.TempDir()
+		err = os.WriteFile(path.Join(root, "go.mod"), []byte("module xyz\n"), 0644)
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		err = EmitInterfaceFromHelper(root, "order", ometa, "call")
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		content, err := os.ReadFile(path.Join(root, "internal", "srpc", "service", "order", "model.go"))
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		f, err := parser.ParseFile(token.NewFileSet(), "model.go", content, 0)
+		if err != nil {
+			t.Errorf("except model.go valid, but got %v", err)
+			return
+		}
+		count := map[string]int{}
+		for _, decl := range f.Decls {
+			if gd, ok := decl.(*ast.GenDecl); ok && gd.Tok == token.TYPE {
+				for _, spec := range gd.Specs {
+					count[spec.(*ast.TypeSpec).Name.Name]++
+				}
+			}
+		}
+		// 类型 Order 与生成的访问函数 Order() 同名, 加上包名作为前缀
+		for _, name := range []string{"Node", "Model6Order", "Customer"} {
+			if count[name] != 1 {
+				t.Errorf("except type %s emitted once, but got %d", name, count[name])
+				return
+			}
+		}
+		if len(count) != 3 || strings.Contains(string(content), "{{") {
+			t.Errorf("except only Node, Model6Order and Customer, but got %s", content)
+			return
+		}
+	}
+}
@@ -1,10 +1,12 @@
 package emit
 
 import (
+	"go/token"
+	"sort"
 	"sr/parse"
 	"sr/util"
-
-	"github.com/aundis/meta"
+	"strconv"
+	"strings"
 )
 
 func resolveImport(file *parse.File, packageName string) *parse.Import {
@@ -16,54 +18,113 @@ func resolveImport(file *parse.File, packageName string) *parse.Import {
 	return nil
 }
 
+// importCollect 收集生成的文件需要导入的包, 为每个包分配文件内唯一的名称
 type importCollect struct {
-	imports map[string]string
+	root string
+	// 包路径 => 名称
+	names map[string]string
+	// 名称 => 包路径
+	paths map[string]string
 }
 
-func newImportCollect() *importCollect {
+func newImportCollect(root string) *importCollect {
 	return &importCollect{
-		imports: map[string]string{},
+		root:  root,
+		names: map[string]string{},
+		paths: map[string]string{},
+	}
+}
+
+// Set 使用指定的名称导入包, 名称被其他包占用时分配新的名称, 返回实际使用的名称
+func (c *importCollect) Set(name string, path string) string {
+	if n, ok := c.names[path]; ok {
+		return n
+	}
+	name = c.allocate(name, path)
+	c.names[path] = name
+	c.paths[name] = path
+	return name
+}
+
+// Add 使用包的真实名称导入包, 返回实际使用的名称
+func (c *importCollect) Add(path string) string {
+	if n, ok := c.names[path]; ok {
+		return n
 	}
+	return c.Set(parse.PackageName(path, c.root), path)
 }
 
-func (c *importCollect) Set(name string, path string) {
-	c.imports[name] = path
+// Name 已导入的包使用的名称, 没有导入时为空
+func (c *importCollect) Name(path string) string {
+	return c.names[path]
 }
 
 func (c *importCollect) Get(name string) string {
-	return c.imports[name]
+	return c.paths[name]
 }
 
+// Imports 所有导入的包, 名称 => 包路径
+func (c *importCollect) Imports() map[string]string {
+	return c.paths
+}
+
+// allocate 名称冲突时依次加上上级目录的名称, e.g. abc/billing/model => billingmodel
+func (c *importCollect) allocate(name, path string) string {
+	if _, ok := c.paths[name]; !ok {
+		return name
+	}
+	parts := strings.Split(path, "/")
+	candidate := name
+	for i := len(parts) - 2; i >= 0; i-- {
+		candidate = strings.ToLower(parse.GuessPackageName(parts[i])) + candidate
+		if _, ok := c.paths[candidate]; !ok && token.IsIdentifier(candidate) {
+			return candidate
+		}
+	}
+	for i := 2; ; i++ {
+		candidate = name + strconv.Itoa(i)
+		if _, ok := c.paths[candidate]; !ok {
+			return candidate
+		}
+	}
+}
+
+// Emit 按包路径的顺序输出 import, 名称与包名不一致时需要写出别名
 func (c *importCollect) Emit(writer util.TextWriter) {
-	for name, path := range c.imports {
-		if util.StringEndOf(path, name) {
-			writer.WriteString(`import "` + path + `"`)
-		} else {
-			writer.WriteString("import " + name + ` "` + path + `"`)
+	var paths []string
+	for path := range c.names {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+	for _, path := range paths {
+		name := c.names[path]
+		if util.StringEndOf(path, "/"+name) || path == name {
+			if name == parse.PackageName(path, c.root) {
+				writer.WriteString(`import "` + path + `"`).WriteLine()
+				continue
+			}
 		}
-		writer.WriteLine()
+		writer.WriteString("import " + name + ` "` + path + `"`).WriteLine()
 	}
 }
 
-func resolveStructImports(st *parse.StructType, collect *importCollect, toPackage, module, root string) error {
-	return resolveFieldImports(st.Parent, getStructFields(st), collect, toPackage, module, root)
+func resolveStructImports(st *parse.StructType, collect *importCollect, toPackage, module, root string, option Option) error {
+	return resolveFieldImports(getStructFields(st), collect, toPackage, module, root, option)
 }
 
-func resolveInterfaceImports(it *parse.InterfaceType, collect *importCollect, toPackage, module, root string) error {
-	return resolveFieldImports(it.Parent, getInterfaceFields(it), collect, toPackage, module, root)
+func resolveInterfaceImports(it *parse.InterfaceType, collect *importCollect, toPackage, module, root string, option Option) error {
+	return resolveFieldImports(getInterfaceFields(it), collect, toPackage, module, root, option)
 }
 
-func resolveFieldImports(file *parse.File, fields []*parse.Field, collect *importCollect, toPackage string, module string, root string) error {
+func resolveFieldImports(fields []*parse.Field, collect *importCollect, toPackage string, module string, root string, option Option) error {
 	for _, field := range fields {
-		if !hasCustomerType(field.Type) {
+		expr := fieldTypeExpr(field.TypeRaw)
+		if expr == nil || !hasCustomType(expr) {
 			continue
 		}
-		resolver := typeResolver{
-			module:   module,
-			root:     root,
-			resolved: map[string]*meta.TypeMeta{},
-		}
-		template, err := resolver.resolve(file, field.Type, field.Pos)
+		file := field.Parent
+		resolver := newTypeResolver(root, module, option)
+		template, err := resolver.resolve(file, expr)
 		if err != nil {
 			return err
 		}
@@ -75,10 +136,10 @@ func resolveFieldImports(file *parse.File, fields []*parse.Field, collect *impor
 				return formatError(file.FileSet, field.Pos, "not found type meta "+id, root)
 			}
 			if tmeta.Import != nil {
-				collect.Set(getImportMetaExport(tmeta.Import), tmeta.Import.Path)
+				collect.Add(tmeta.Import.Path)
 			}
 			if len(tmeta.Code) > 0 && tmeta.From != toPackage {
-				collect.Set(getImportPathExport(tmeta.From), tmeta.From)
+				collect.Add(tmeta.From)
 			}
 		}
 	}
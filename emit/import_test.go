@@ -0,0 +1,43 @@
+package emit
+
+import (
+	"sr/util"
+	"strings"
+	"testing"
+)
+
+func TestImportCollect(t *testing.T) {
+	// 不在模块中, 包名只能根据路径推断
+	collect := newImportCollect(t.TempDir())
+	if name := collect.Add("abc/internal/model"); name != "model" {
+		t.Errorf("except name model but got %s", name)
+		return
+	}
+	if name := collect.Add("abc/internal/billing/model"); name != "billingmodel" {
+		t.Errorf("except name billingmodel but got %s", name)
+		return
+	}
+	if name := collect.Add("github.com/go-redis/redis/v8"); name != "redis" {
+		t.Errorf("except name redis but got %s", name)
+		return
+	}
+	if name := collect.Add("abc/internal/model"); name != "model" {
+		t.Errorf("except name model but got %s", name)
+		return
+	}
+	if path := collect.Get("billingmodel"); path != "abc/internal/billing/model" {
+		t.Errorf("except path abc/internal/billing/model but got %s", path)
+		return
+	}
+	writer := util.NewTextWriter()
+	collect.Emit(writer)
+	except := []string{
+		`import billingmodel "abc/internal/billing/model"`,
+		`import "abc/internal/model"`,
+		`import redis "github.com/go-redis/redis/v8"`,
+	}
+	lines := strings.Split(strings.TrimSpace(string(writer.Bytes())), "\n")
+	if strings.Join(lines, "\n") != strings.Join(except, "\n") {
+		t.Errorf("except imports %v but got %v", except, lines)
+	}
+}
@@ -0,0 +1,92 @@
+package emit
+
+import (
+	"errors"
+	"sr/parse"
+	"sr/util"
+	"strconv"
+	"strings"
+)
+
+// actionLimit 方法上使用 //sr:limit 指令声明的限制, e.g. //sr:limit concurrency=4 rate=10 burst=20,
+// 运行时配置 srpc.limit 中出现的项覆盖这里的值, 设置为 0 或 -1 取消限制
+type actionLimit struct {
+	// 同时处理的请求数量上限
+	concurrency int
+	// 每秒允许的请求数量, 可以是小数
+	rate float64
+	// 令牌桶的容量, 默认为 rate 向上取整
+	burst int
+}
+
+func parseLimit(args string) (*actionLimit, error) {
+	fields := strings.Fields(args)
+	if len(fields) == 0 {
+		return nil, errors.New("directive //sr:limit requires at least one of concurrency, rate and burst")
+	}
+	limit := &actionLimit{}
+	for _, field := range fields {
+		key, value, ok := strings.Cut(field, "=")
+		if !ok {
+			return nil, errors.New("directive //sr:limit requires key=value, but got " + field)
+		}
+		var err error
+		switch key {
+		case "concurrency":
+			limit.concurrency, err = strconv.Atoi(value)
+			if err == nil && limit.concurrency <= 0 {
+				err = errors.New("must be positive")
+			}
+		case "rate":
+			limit.rate, err = strconv.ParseFloat(value, 64)
+			if err == nil && limit.rate <= 0 {
+				err = errors.New("must be positive")
+			}
+		case "burst":
+			limit.burst, err = strconv.Atoi(value)
+			if err == nil && limit.burst <= 0 {
+				err = errors.New("must be positive")
+			}
+		default:
+			return nil, errors.New("unknown limit " + key + " in directive //sr:limit")
+		}
+		if err != nil {
+			return nil, errors.New("invalid " + key + " " + value + " in directive //sr:limit, a positive number is required")
+		}
+	}
+	if limit.burst > 0 && limit.rate == 0 {
+		return nil, errors.New("burst in directive //sr:limit requires rate")
+	}
+	return limit, nil
+}
+
+// methodLimit 方法上声明的限制, 没有时返回 nil, 指令已经在 checkDirectives 中校验过
+func methodLimit(fun *parse.Function) *actionLimit {
+	d := parse.FindDirective(fun.Directives, directiveLimit)
+	if d == nil {
+		return nil
+	}
+	limit, err := parseLimit(d.Args)
+	if err != nil {
+		return nil
+	}
+	return limit
+}
+
+// emitLimit 注册声明的限制, e.g. manager.SetLimit("Order.Search", manager.Limit{Concurrency: 4, Rate: 10})
+func emitLimit(writer util.TextWriter, action string, limit *actionLimit) {
+	if limit == nil {
+		return
+	}
+	var fields []string
+	if limit.concurrency > 0 {
+		fields = append(fields, "Concurrency: "+strconv.Itoa(limit.concurrency))
+	}
+	if limit.rate > 0 {
+		fields = append(fields, "Rate: "+strconv.FormatFloat(limit.rate, 'g', -1, 64))
+	}
+	if limit.burst > 0 {
+		fields = append(fields, "Burst: "+strconv.Itoa(limit.burst))
+	}
+	writer.WriteString(`manager.SetLimit("`, action, `", manager.Limit{`, strings.Join(fields, ", "), "})").WriteLine()
+}
@@ -0,0 +1,43 @@
+package emit
+
+import (
+	"sr/util"
+	"strings"
+	"testing"
+)
+
+func TestParseLimit(t *testing.T) {
+	cases := []struct {
+		args   string
+		except string
+		err    string
+	}{
+		{"concurrency=4 rate=10 burst=20", `manager.SetLimit("Order.Search", manager.Limit{Concurrency: 4, Rate: 10, Burst: 20})`, ""},
+		{"rate=0.5", `manager.SetLimit("Order.Search", manager.Limit{Rate: 0.5})`, ""},
+		{"", "", "requires at least one of"},
+		{"concurrency", "", "requires key=value"},
+		{"timeout=3", "", "unknown limit timeout"},
+		{"rate=-1", "", "a positive number is required"},
+		{"burst=5", "", "burst in directive //sr:limit requires rate"},
+	}
+	for _, c := range cases {
+		limit, err := parseLimit(c.args)
+		if len(c.err) > 0 {
+			if err == nil || !strings.Contains(err.Error(), c.err) {
+				t.Errorf("except error %s but got %v", c.err, err)
+				return
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("except no error but got %v", err)
+			return
+		}
+		writer := util.NewTextWriter()
+		emitLimit(writer, "Order.Search", limit)
+		if code := strings.TrimSpace(string(writer.Bytes())); code != c.except {
+			t.Errorf("except code %s but got %s", c.except, code)
+			return
+		}
+	}
+}
@@ -9,7 +9,7 @@ import (
 	"strconv"
 )
 
-func EmitListen(root string) error {
+func EmitListen(root string, option ...Option) error {
 	// 取项目模块名
 	module, err := getProjectModuleName(root)
 	if err != nil {
@@ -25,8 +25,9 @@ func EmitListen(root string) error {
 	if err != nil {
 		return err
 	}
+	opt := firstOption(option)
 	for _, dir := range dirs {
-		err = emitListenDir(root, module, dir)
+		err = emitListenDir(root, module, dir, opt)
 		if err != nil {
 			return err
 		}
@@ -53,7 +54,7 @@ func EmitListen(root string) error {
 	return nil
 }
 
-func emitListenDir(root, module string, dir string) error {
+func emitListenDir(root, module string, dir string, option Option) error {
 	base := path.Base(dir)
 	// 删除历史生成的文件
 	err := util.RemoveGenerateFiles(path.Join(dir, "listen"))
@@ -75,22 +76,30 @@ func emitListenDir(root, module string, dir string) error {
 		return nil
 	}
 	// 拿到所有的接口类型
-	var interfaceTypes []*parse.InterfaceType
+	var astFiles []*parse.File
 	for _, filename := range goFiles {
 		astFile, err := parse.ParseFile(filename)
 		if err != nil {
 			return err
 		}
-		for _, it := range astFile.InterfaceTypes {
-			// 只处理I开头的interface
-			if len(it.Name) == 0 {
-				continue
-			}
-			if it.Name[0] != 'I' {
-				continue
-			}
-			interfaceTypes = append(interfaceTypes, it)
+		astFiles = append(astFiles, astFile)
+	}
+	resolver := newTypeResolver(root, module, option)
+	var interfaceTypes []*parse.InterfaceType
+	for _, it := range parse.CombineInterfaceTypes(astFiles) {
+		// 只处理I开头的interface
+		if len(it.Name) == 0 {
+			continue
+		}
+		if it.Name[0] != 'I' {
+			continue
 		}
+		// 展开其他文件或其他包中的嵌入接口
+		err = resolver.expandInterface(it)
+		if err != nil {
+			return err
+		}
+		interfaceTypes = append(interfaceTypes, it)
 	}
 	// 内容不生成文件
 	if len(interfaceTypes) == 0 {
@@ -99,7 +108,7 @@ func emitListenDir(root, module string, dir string) error {
 	// 生成
 	for _, it := range interfaceTypes {
 		target := base
-		err = emitListenStruct(root, module, target, it)
+		err = emitListenStruct(root, module, target, it, option)
 		if err != nil {
 			return err
 		}
@@ -107,14 +116,16 @@ func emitListenDir(root, module string, dir string) error {
 	return nil
 }
 
-func emitListenStruct(root, module, target string, it *parse.InterfaceType) error {
+func emitListenStruct(root, module, target string, it *parse.InterfaceType, option Option) error {
 	e := &listenStructEmiter{
 		writer:   util.NewTextWriter(),
 		root:     root,
 		module:   module,
 		target:   target,
+		option:   option,
 		exportTo: fmt.Sprintf("%s/internal/srpc/service/%s/listen", module, target),
 		it:       it,
+		smap:     util.NewSourceMap(),
 	}
 	return e.emit()
 }
@@ -124,8 +135,11 @@ type listenStructEmiter struct {
 	module   string
 	target   string
 	exportTo string
+	option   Option
+	imports  *importCollect
 	it       *parse.InterfaceType
 	writer   util.TextWriter
+	smap     *util.SourceMap
 }
 
 func (e *listenStructEmiter) emit() error {
@@ -148,6 +162,10 @@ func (e *listenStructEmiter) emit() error {
 	if err != nil {
 		return err
 	}
+	err = util.WriteSourceMap(outPath, e.smap)
+	if err != nil {
+		return err
+	}
 	return nil
 }
 
@@ -163,7 +181,7 @@ func (e *listenStructEmiter) emitHeader() error {
 }
 
 func (e *listenStructEmiter) emitImports() error {
-	collect := newImportCollect()
+	collect := newImportCollect(e.root)
 	collect.Set("context", "context")
 	collect.Set("json", "encoding/json")
 	// collect.Set("srpc", "github.com/aundis/srpc")
@@ -171,42 +189,43 @@ func (e *listenStructEmiter) emitImports() error {
 	collect.Set("manager", e.module+"/internal/srpc/manager")
 	collect.Set("garray", "github.com/gogf/gf/v2/container/garray")
 	collect.Set(e.target, e.module+"/internal/srpc/service/"+e.target)
-	err := resolveInterfaceImports(e.it, collect, e.exportTo, e.module, e.root)
+	err := resolveInterfaceImports(e.it, collect, e.exportTo, e.module, e.root, e.option)
 	if err != nil {
 		return err
 	}
 	collect.Emit(e.writer)
+	e.imports = collect
 	return nil
 }
 
 func (e *listenStructEmiter) emitBody() error {
-	fResolver := newFieldResolver(e.root, e.module, e.exportTo)
+	fResolver := newFieldResolver(e.root, e.module, e.exportTo, e.imports, e.option)
 	// 检查函数签名是否合法
 	var paramAndResultArr []paramAndResult
 	for _, fun := range e.it.Functions {
 		if len(fun.Name) < 2 || string(fun.Name[:2]) != "On" {
-			return formatError(e.it.Parent.FileSet, fun.Pos, "listen interface function name must start with On", e.root)
+			return formatError(fun.Parent.FileSet, fun.Pos, "listen interface function name must start with On", e.root)
 		}
 		if len(fun.Params) != 1 {
-			return formatError(e.it.Parent.FileSet, fun.Pos, "listen interface function params count must be 1", e.root)
+			return formatError(fun.Parent.FileSet, fun.Pos, "listen interface function params count must be 1", e.root)
 		}
 		if !parse.IsFuncType(fun.Params[0].TypeRaw) {
-			return formatError(e.it.Parent.FileSet, fun.Params[0].Pos, "listen interface function first params type must be function type", e.root)
+			return formatError(fun.Parent.FileSet, fun.Params[0].Pos, "listen interface function first params type must be function type", e.root)
 		}
 		firstParam := fun.Params[0]
 		funcType := firstParam.TypeRaw.(*ast.FuncType)
-		params, results := parse.ParseFuncType(e.it.Parent.Content, funcType, firstParam.Parent)
+		params, results := parse.ParseFuncType(fun.Parent.Content, funcType, firstParam.Parent)
 		if len(params) == 0 {
-			return formatError(e.it.Parent.FileSet, funcType.Pos(), "the function type has at least one parameter", e.root)
+			return formatError(fun.Parent.FileSet, funcType.Pos(), "the function type has at least one parameter", e.root)
 		}
 		if params[0].Type != "context.Context" {
-			return formatError(e.it.Parent.FileSet, funcType.Pos(), "the function type first param type must be context.Context", e.root)
+			return formatError(fun.Parent.FileSet, funcType.Pos(), "the function type first param type must be context.Context", e.root)
 		}
 		if len(results) == 0 {
-			return formatError(e.it.Parent.FileSet, funcType.Pos(), "the function type must has a return type", e.root)
+			return formatError(fun.Parent.FileSet, funcType.Pos(), "the function type must has a return type", e.root)
 		}
 		if results[0].Type != "error" {
-			return formatError(e.it.Parent.FileSet, results[0].Pos, "the function type first return type must be error", e.root)
+			return formatError(fun.Parent.FileSet, results[0].Pos, "the function type first return type must be error", e.root)
 		}
 		// 替换params,results的类型
 		var fields []*parse.Field
@@ -243,9 +262,18 @@ func (e *listenStructEmiter) emitBody() error {
 		// results := paramAndResultArr[i].results
 
 		action := e.target + "@" + e.it.Name[1:] + "." + orgFunctionName
+		start := writer.Line()
 		writer.WriteString(`manager.AddController("`, action, `", func(ctx context.Context, req []byte) (res interface{}, err error) {`).WriteLine().IncreaseIndent()
+		writer.WriteString(`defer manager.Recover(ctx, "`, action, `", &err)`).WriteLine()
 		if len(params) > 1 {
 			reqStructName := firstLower(e.it.Name[1:]) + orgFunctionName + `Request`
+			// 兼容旧版本发送方的位置键
+			if keys := renameKeysCode(e.option, params[1:], "p"); len(keys) > 0 {
+				writer.WriteString("req, err = manager.RenameKeys(req, ", keys, ")").WriteLine()
+				writer.WriteString(`if err != nil {`).WriteLine().IncreaseIndent()
+				writer.WriteString(`return`).WriteLine()
+				writer.DecreaseIndent().WriteString("}").WriteLine()
+			}
 			writer.WriteString(`var params *`, reqStructName).WriteLine()
 			writer.WriteString(`err = json.Unmarshal(req, &params)`).WriteLine()
 			writer.WriteString(`if err != nil {`).WriteLine().IncreaseIndent()
@@ -267,6 +295,7 @@ func (e *listenStructEmiter) emitBody() error {
 		writer.WriteString("res = map[string]interface{}{}").WriteLine()
 		writer.WriteString("return").WriteLine()
 		writer.DecreaseIndent().WriteString("})").WriteLine()
+		e.addSourceMapping(start, fun)
 	}
 	// writer.WriteString("service.Register", ometa.Name, "(&s", ometa.Name, "{})").WriteLine()
 	writer.DecreaseIndent().WriteString("}").WriteLine()
@@ -280,16 +309,18 @@ func (e *listenStructEmiter) emitBody() error {
 		}
 		reqStructName := firstLower(e.it.Name[1:]) + orgFunctionName + `Request`
 		writer.WriteEmptyLine()
+		start := writer.Line()
 		writer.WriteString("type ", reqStructName, " struct {").WriteLine().IncreaseIndent()
 		for i, param := range params {
 			if i == 0 {
 				continue
 			}
-			// P1 int `json:"p1"`
+			// P1 int `json:"name"`
 			name := "P" + strconv.Itoa(i)
-			writer.WriteString(name, " ", fResolver.getResolvedType(param), " `json:\"", firstLower(name), "\"`").WriteLine()
+			writer.WriteString(name, " ", fResolver.getResolvedType(param), " `json:\"", wireKey(e.option, param, "p", i), "\"`").WriteLine()
 		}
 		writer.DecreaseIndent().WriteString("}").WriteLine()
+		e.addSourceMapping(start, fun)
 	}
 
 	// var boxBoomFuncs = garray.New(true)
@@ -312,6 +343,7 @@ func (e *listenStructEmiter) emitBody() error {
 		arrayName := firstLower(objectName) + orgFunctionName + "Funcs"
 		// results := paramAndResultArr[i].results
 		writer.WriteEmptyLine()
+		start := writer.Line()
 		writer.WriteString("func (l *l", objectName, ") On", orgFunctionName, "(fun func(")
 		for i, p := range params {
 			if i > 0 {
@@ -322,6 +354,7 @@ func (e *listenStructEmiter) emitBody() error {
 		writer.WriteString(") error) {").WriteLine().IncreaseIndent()
 		writer.WriteString(arrayName, ".Append(fun)").WriteLine()
 		writer.DecreaseIndent().WriteString("}").WriteLine()
+		e.addSourceMapping(start, fun)
 	}
 
 	for i, fun := range e.it.Functions {
@@ -329,6 +362,7 @@ func (e *listenStructEmiter) emitBody() error {
 		params := paramAndResultArr[i].params
 		arrayName := firstLower(objectName) + orgFunctionName + "Funcs"
 		writer.WriteEmptyLine()
+		start := writer.Line()
 		writer.WriteString("func (l *l", objectName, ") ", firstLower(orgFunctionName), "(")
 		for i, p := range params {
 			if i > 0 {
@@ -381,11 +415,16 @@ func (e *listenStructEmiter) emitBody() error {
 		writer.WriteString("return").WriteLine()
 		// method
 		writer.DecreaseIndent().WriteString("}").WriteLine()
+		e.addSourceMapping(start, fun)
 	}
 
 	return nil
 }
 
+func (e *listenStructEmiter) addSourceMapping(start int, fun *parse.Function) {
+	addSourceMapping(e.smap, e.writer, start, fun.Parent.FileSet, fun.Pos, fun.End, e.it.Name+"."+fun.Name, e.root)
+}
+
 // func (e *listenStructEmiter) formatType(tpe string) string {
 // 	reg := regexp.MustCompile(`\b(\.?[A-Z]\w*\.?)\b`)
 // 	return reg.ReplaceAllStringFunc(tpe, func(s string) string {
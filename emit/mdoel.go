@@ -15,14 +15,11 @@ func emitModel(model *parse.Model, out string, root string) error {
 	if len(model.GetImports()) > 0 {
 		writer.WriteEmptyLine()
 	}
+	collect := newImportCollect(root)
 	for name, path := range model.GetImports() {
-		if util.StringEndOf(path, name) {
-			writer.WriteString(`import "` + path + `"`)
-		} else {
-			writer.WriteString("import " + name + ` "` + path + `"`)
-		}
-		writer.WriteLine()
+		collect.Set(name, path)
 	}
+	collect.Emit(writer)
 	for _, v := range model.GetTypes() {
 		writer.WriteEmptyLine()
 		writer.Write(v.Content)
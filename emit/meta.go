@@ -0,0 +1,58 @@
+package emit
+
+// 元数据结构与 github.com/aundis/meta 的 JSON 格式保持一致, 在此基础上扩展了额外的信息,
+// 生成代码中 manager 包的模板 project/internal/srpc/manager/meta.go.txt 由此文件生成, 修改后运行 go generate 更新
+
+//go:generate go test -run TestMetaTemplate -update
+
+type ObjectMeta struct {
+	Name      string          `json:"name"`
+	Kind      string          `json:"kind"`
+	Doc       string          `json:"doc,omitempty"`
+	Functions []*FunctionMeta `json:"functions"`
+	// 提供方生成的契约模块中的包, 调用方可以导入其中的类型而不是复制
+	Contract string `json:"contract,omitempty"`
+	// 允许调用的服务名称, 为空时不限制
+	Allow []string `json:"allow,omitempty"`
+	// meta.Slot 标签中声明的版本和负责人
+	Version string `json:"version,omitempty"`
+	Owner   string `json:"owner,omitempty"`
+}
+
+type FunctionMeta struct {
+	Name       string
+	Doc        string `json:"Doc,omitempty"`
+	Deprecated string `json:"Deprecated,omitempty"`
+	// 允许调用的服务名称, 优先于对象上的声明
+	Allow []string `json:"Allow,omitempty"`
+	// 服务端运行时生效的执行时间上限, e.g. 5s, 在 Helper.list 中填入, 调用方的超时时间应当大于这个值
+	Timeout    string `json:"Timeout,omitempty"`
+	Parameters []*FieldMeta
+	Results    []*FieldMeta
+}
+
+type FieldMeta struct {
+	Name      string      `json:"name"`
+	Type      string      `json:"type"`
+	TypeMetas []*TypeMeta `json:"typeMetas"`
+}
+
+type TypeMeta struct {
+	Id     string      `json:"id"`
+	Name   string      `json:"name"`
+	From   string      `json:"from"`
+	Code   string      `json:"code"`
+	Import *ImportMeta `json:"imports"`
+	// 枚举类型的可选值
+	Values []*ValueMeta `json:"values,omitempty"`
+}
+
+type ImportMeta struct {
+	Path  string `json:"path"`
+	Alias string `json:"alias"`
+}
+
+type ValueMeta struct {
+	Name  string `json:"name"`
+	Value string `json:"value"`
+}
@@ -0,0 +1,66 @@
+package emit
+
+import (
+	"bytes"
+	"flag"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/token"
+	"io/ioutil"
+	"testing"
+)
+
+var update = flag.Bool("update", false, "update the manager meta template from meta.go")
+
+const metaTemplateFile = "../project/internal/srpc/manager/meta.go.txt"
+
+// metaTemplate 由 meta.go 生成 manager 包中的元数据结构
+func metaTemplate(src []byte) ([]byte, error) {
+	fset := token.NewFileSet()
+	f, err := parser.ParseFile(fset, "meta.go", src, parser.ParseComments)
+	if err != nil {
+		return nil, err
+	}
+	pos := f.Decls[0].Pos()
+	if decl, ok := f.Decls[0].(*ast.GenDecl); ok && decl.Doc != nil {
+		pos = decl.Doc.Pos()
+	}
+	start := fset.Position(pos).Offset
+	var buf bytes.Buffer
+	buf.WriteString("// ==========================================================================\n")
+	buf.WriteString("// Code generated by Srpc CLI tool. DO NOT EDIT.\n")
+	buf.WriteString("// ==========================================================================\n\n")
+	buf.WriteString("package manager\n\n")
+	buf.WriteString("// 元数据结构由 sr 的 emit/meta.go 生成, 与 github.com/aundis/meta 的 JSON 格式保持一致\n\n")
+	buf.Write(src[start:])
+	return format.Source(buf.Bytes())
+}
+
+// TestMetaTemplate 模板中的元数据结构与 meta.go 不一致时失败, 使用 -update 重新生成
+func TestMetaTemplate(t *testing.T) {
+	src, err := ioutil.ReadFile("meta.go")
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	except, err := metaTemplate(src)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	if *update {
+		if err := ioutil.WriteFile(metaTemplateFile, except, 0644); err != nil {
+			t.Error(err)
+		}
+		return
+	}
+	got, err := ioutil.ReadFile(metaTemplateFile)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	if !bytes.Equal(got, except) {
+		t.Errorf("except %s generated from meta.go, run go generate ./emit to update it", metaTemplateFile)
+	}
+}
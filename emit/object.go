@@ -0,0 +1,56 @@
+package emit
+
+import (
+	"go/token"
+	"reflect"
+	"sr/parse"
+	"unicode"
+)
+
+// meta.Slot 的标签声明远程对象的信息, e.g. meta.Slot `name:"Order" version:"v2" description:"订单服务" owner:"trade"`,
+// name 决定调用的名称, 生成的文件名称和注册的元数据, 没有声明时按 GoFrame 约定由结构体名称得到
+
+// slotField 结构体中嵌入的 meta.Slot 字段
+func slotField(st *parse.StructType) *parse.Field {
+	for _, v := range st.Fields {
+		if v.Embedded && v.Type == "meta.Slot" {
+			return v
+		}
+	}
+	return nil
+}
+
+func slotTag(st *parse.StructType) reflect.StructTag {
+	if f := slotField(st); f != nil {
+		return unquoteTag(string(f.Tag))
+	}
+	return ""
+}
+
+// slotObjectName 远程对象的名称, 标签中的 name 优先
+func slotObjectName(st *parse.StructType) string {
+	if name := slotTag(st).Get("name"); len(name) > 0 {
+		return name
+	}
+	return serviceName(st)
+}
+
+// serviceName GoFrame 生成的 service 中的名称, sXxx 结构体去掉前缀 s
+func serviceName(st *parse.StructType) string {
+	if len(st.Name) > 1 && st.Name[0] == 's' && unicode.IsUpper(rune(st.Name[1])) {
+		return st.Name[1:]
+	}
+	return st.Name
+}
+
+// checkSlotTag 检查 meta.Slot 标签中的名称, 名称会用于生成的标识符
+func checkSlotTag(st *parse.StructType, root string) error {
+	f := slotField(st)
+	if f == nil {
+		return nil
+	}
+	if name, ok := unquoteTag(string(f.Tag)).Lookup("name"); ok && (!token.IsIdentifier(name) || !token.IsExported(name)) {
+		return formatError(st.Parent.FileSet, f.Pos, "name in meta.Slot tag requires an exported identifier, but got \""+name+"\"", root)
+	}
+	return nil
+}
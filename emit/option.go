@@ -0,0 +1,48 @@
+package emit
+
+import "sr/parse"
+
+// Option 代码生成的选项, 可以通过命令行参数或配置文件中的 srpc.gen 节点设置
+type Option struct {
+	// 使用 go/types 对源码做类型检查, 解析出参数和返回值类型的真实声明
+	TypeCheck bool `json:"typeCheck"`
+	// 扫描源码时额外启用的构建标签, 与 go build -tags 相同
+	Tags []string `json:"tags"`
+	// 严格模式, 传输的结构体的导出字段都必须有 json 标签
+	Strict bool `json:"strict"`
+	// 复制类型源码而不是导入的第三方包, 与 go 命令的包模式相同, e.g. github.com/foo/dto/...
+	Vendor []string `json:"vendor"`
+	// 复制结构体等类型时携带可以独立复制的方法, 如 MarshalJSON, UnmarshalJSON 和 Validate
+	Methods bool `json:"methods"`
+	// 生成契约模块的目录, 相对于项目根目录, 为空时不生成, e.g. contract
+	Contract string `json:"contract"`
+	// 参数和返回值传输时使用的键: name (默认), position 或 compat
+	WireKeys string `json:"wireKeys"`
+	// internal/logic 之外扫描 slot 结构体的目录, 相对于项目根目录, 以 /... 结尾时包含子目录, e.g. internal/billing/...,
+	// 其中的结构体与 internal/logic 相同按 sXxx 命名, 并通过 manager.Provide 注册实例
+	SlotDirs []string `json:"slotDirs"`
+	// 远程调用默认的执行时间上限, 方法上的 //sr:timeout 指令优先, e.g. 30s
+	Timeout string `json:"timeout"`
+	// 类型检查模式下同一次生成中共用的 Checker, 缓存检查过的包
+	checker *parse.Checker
+}
+
+// CheckOption 校验选项的取值
+func CheckOption(option Option) error {
+	err := checkWireKeys(option)
+	if err != nil {
+		return err
+	}
+	return checkTimeoutOption(option)
+}
+
+func firstOption(option []Option) Option {
+	var first Option
+	if len(option) > 0 {
+		first = option[0]
+	}
+	if first.TypeCheck && first.checker == nil {
+		first.checker = parse.NewChecker(first.Tags...)
+	}
+	return first
+}
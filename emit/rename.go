@@ -0,0 +1,343 @@
+package emit
+
+import (
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"os"
+	"path"
+	"sort"
+	"sr/parse"
+	"sr/util"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// 复制到 model.go 中的类型用 //sr:from 指令记录来源, e.g. //sr:from abc/internal/model/user.Profile
+const directiveFrom = "from"
+
+// 生成的代码中使用的名称在 taken 中的来源, 与任何类型来源都不相同
+const reservedOrigin = "-"
+
+// allocateTypeNames 为复制到同一个包中的类型分配名称, 来自不同包的同名类型会加上包名作为前缀,
+// 已经存在于 model.go 中的类型沿用之前分配的名称, 重新获取时名称保持不变
+func (e *helperInterfaceEmiter) allocateTypeNames() error {
+	// 导出包 => 类型来源
+	origins := map[string][]string{}
+	seen := map[string]bool{}
+	for _, tmeta := range e.tmetas {
+		if len(tmeta.Code) == 0 {
+			continue
+		}
+		pkg := e.exportTo[tmeta.Id]
+		origin := tmeta.From + "." + tmeta.Name
+		e.origins[tmeta.Id] = origin
+		if seen[pkg+"@"+origin] {
+			continue
+		}
+		seen[pkg+"@"+origin] = true
+		origins[pkg] = append(origins[pkg], origin)
+	}
+	// 类型来源 => 类型携带的常量
+	consts := map[string][]string{}
+	for _, tmeta := range e.tmetas {
+		if len(tmeta.Code) > 0 {
+			consts[e.origins[tmeta.Id]] = constNamesInCode(tmeta.Code)
+		}
+	}
+	names := map[string]map[string]string{}
+	e.constNames = map[string]map[string]string{}
+	for pkg, list := range origins {
+		model, err := parse.ParseFileModel(path.Join(e.packageDir(pkg), "model.go"))
+		if err != nil {
+			return err
+		}
+		var reserved []string
+		if pkg == e.toPackage {
+			reserved, err = e.reservedNames()
+			if err != nil {
+				return err
+			}
+		}
+		var constNames map[string]map[string]string
+		names[pkg], constNames = allocateNames(model, list, consts, reserved)
+		for origin, m := range constNames {
+			e.constNames[pkg+"@"+origin] = m
+		}
+	}
+	for _, tmeta := range e.tmetas {
+		if len(tmeta.Code) == 0 {
+			continue
+		}
+		tmeta.Name = names[e.exportTo[tmeta.Id]][e.origins[tmeta.Id]]
+	}
+	return nil
+}
+
+// reservedNames 包中生成的代码使用的名称, 包括当前对象和目录中已经生成的对象的接口和访问函数
+func (e *helperInterfaceEmiter) reservedNames() ([]string, error) {
+	objects := []string{e.ometa.Name}
+	files, err := parse.ListGoFiles(e.outDir)
+	if err != nil && !os.IsNotExist(err) {
+		return nil, err
+	}
+	for _, filename := range files {
+		is, err := util.IsGenerateFile(filename)
+		if err != nil {
+			return nil, err
+		}
+		if !is || path.Base(filename) == "model.go" {
+			continue
+		}
+		f, err := parse.ParseFile(filename)
+		if err != nil {
+			return nil, err
+		}
+		for _, it := range f.InterfaceTypes {
+			if strings.HasPrefix(it.Name, "I") && len(it.Name) > 1 {
+				objects = append(objects, it.Name[1:])
+			}
+		}
+	}
+	var result []string
+	for _, name := range objects {
+		result = append(result, "I"+name)
+		// 契约模块只包含接口
+		if e.kind != "contract" {
+			result = append(result, name, "Register"+name, "local"+name)
+		}
+	}
+	return result, nil
+}
+
+// allocateNames 为类型来源和类型携带的常量分配名称, 结果与 list 的顺序无关,
+// consts 为类型来源 => 常量名称, reserved 为生成的代码已经使用的名称,
+// 返回类型来源 => 名称, 以及类型来源 => 常量名称 => 分配的名称
+func allocateNames(model *parse.Model, list []string, consts map[string][]string, reserved []string) (map[string]string, map[string]map[string]string) {
+	// 已有类型和常量的名称 => 所属类型的来源, 旧版本生成的类型没有记录来源
+	taken := map[string]string{}
+	result := map[string]string{}
+	constResult := map[string]map[string]string{}
+	isReserved := map[string]bool{}
+	for _, name := range reserved {
+		isReserved[name] = true
+	}
+	for name, tpe := range model.GetTypes() {
+		origin := modelTypeOrigin(tpe)
+		taken[name] = origin
+		if len(origin) > 0 && !isReserved[name] {
+			result[origin] = name
+		}
+		for _, block := range tpe.Consts {
+			for _, c := range block.Consts {
+				taken[c.Name] = origin
+			}
+		}
+	}
+	for _, name := range reserved {
+		taken[name] = reservedOrigin
+	}
+	sorted := make([]string, len(list))
+	copy(sorted, list)
+	sort.Slice(sorted, func(i, j int) bool {
+		ni, nj := originName(sorted[i]), originName(sorted[j])
+		if ni != nj {
+			return ni < nj
+		}
+		return sorted[i] < sorted[j]
+	})
+	for _, origin := range sorted {
+		if _, ok := result[origin]; ok {
+			continue
+		}
+		name := originName(origin)
+		// 没有记录来源的类型直接覆盖
+		if other, ok := taken[name]; ok && len(other) > 0 && other != origin {
+			name = prefixedName(originPackage(origin), name, origin, taken)
+		}
+		taken[name] = origin
+		result[origin] = name
+	}
+	// 常量与类型在同一个包中, 与其他类型的名称或常量冲突时同样加上包名作为前缀
+	for _, origin := range sorted {
+		for _, c := range consts[origin] {
+			name := c
+			// 没有记录来源的类型不会被覆盖, 它的常量仍然存在
+			if other, ok := taken[name]; ok && other != origin {
+				name = prefixedName(originPackage(origin), c, origin, taken)
+			}
+			taken[name] = origin
+			if constResult[origin] == nil {
+				constResult[origin] = map[string]string{}
+			}
+			constResult[origin][c] = name
+		}
+	}
+	return result, constResult
+}
+
+// prefixedName 依次使用包路径中的元素作为前缀, e.g. abc/model/billing.Profile => BillingProfile, ModelBillingProfile,
+// 已经属于 owner 的名称可以继续使用
+func prefixedName(pkg, name, owner string, taken map[string]string) string {
+	free := func(name string) bool {
+		other, ok := taken[name]
+		return !ok || other == owner
+	}
+	parts := strings.Split(pkg, "/")
+	for i := len(parts) - 1; i >= 0; i-- {
+		name = exportedIdent(parts[i]) + name
+		if free(name) {
+			return name
+		}
+	}
+	for i := 2; ; i++ {
+		if free(name + strconv.Itoa(i)) {
+			return name + strconv.Itoa(i)
+		}
+	}
+}
+
+// constNamesInCode 类型代码中携带的常量名称
+func constNamesInCode(code string) []string {
+	const header = "package p\n\n"
+	masked := regPseudocode.ReplaceAllStringFunc(code, func(s string) string {
+		return strings.Repeat("_", len(s))
+	})
+	f, err := parser.ParseFile(token.NewFileSet(), "", header+masked, 0)
+	if err != nil {
+		return nil
+	}
+	var result []string
+	for _, decl := range f.Decls {
+		gen, ok := decl.(*ast.GenDecl)
+		if !ok || gen.Tok != token.CONST {
+			continue
+		}
+		for _, spec := range gen.Specs {
+			for _, name := range spec.(*ast.ValueSpec).Names {
+				if name.Name != "_" {
+					result = append(result, name.Name)
+				}
+			}
+		}
+	}
+	return result
+}
+
+// renameTypeIdent 将类型代码中对类型 from 的引用改为 to, 字段名, 方法名和选择器不受影响,
+// 其他类型的引用仍是占位符, 不会被修改
+func renameTypeIdent(code, from, to string) string {
+	return renameIdents(code, map[string]string{from: to}, true)
+}
+
+// renameConstIdents 将类型代码中对常量的引用按 names 同时改名, 常量可以作为 map 字面量的键
+func renameConstIdents(code string, names map[string]string) string {
+	return renameIdents(code, names, false)
+}
+
+// renameIdents skipKeys 为 true 时不修改复合字面量中的键, 即结构体的字段名
+func renameIdents(code string, names map[string]string, skipKeys bool) string {
+	const header = "package p\n\n"
+	// 占位符不是合法的标识符, 替换成相同长度的标识符后再解析
+	masked := regPseudocode.ReplaceAllStringFunc(code, func(s string) string {
+		return strings.Repeat("_", len(s))
+	})
+	fset := token.NewFileSet()
+	f, err := parser.ParseFile(fset, "", header+masked, 0)
+	if err != nil {
+		return code
+	}
+	skip := map[*ast.Ident]bool{}
+	var idents []*ast.Ident
+	ast.Inspect(f, func(n ast.Node) bool {
+		switch x := n.(type) {
+		case *ast.Field:
+			for _, name := range x.Names {
+				skip[name] = true
+			}
+		case *ast.FuncDecl:
+			skip[x.Name] = true
+		case *ast.SelectorExpr:
+			skip[x.Sel] = true
+		case *ast.KeyValueExpr:
+			if key, ok := x.Key.(*ast.Ident); ok && skipKeys {
+				skip[key] = true
+			}
+		case *ast.Ident:
+			if _, ok := names[x.Name]; ok {
+				idents = append(idents, x)
+			}
+		}
+		return true
+	})
+	var sb strings.Builder
+	last := 0
+	for _, ident := range idents {
+		if skip[ident] {
+			continue
+		}
+		offset := fset.Position(ident.Pos()).Offset - len(header)
+		sb.WriteString(code[last:offset])
+		sb.WriteString(names[ident.Name])
+		last = offset + len(ident.Name)
+	}
+	sb.WriteString(code[last:])
+	return sb.String()
+}
+
+// insertDirective 在类型声明的注释末尾加上指令
+func insertDirective(code string, d *parse.Directive) string {
+	lines := strings.SplitAfter(code, "\n")
+	var doc string
+	i := 0
+	for ; i < len(lines) && strings.HasPrefix(lines[i], "//"); i++ {
+		doc += lines[i]
+	}
+	directive := parse.DocWithDirectives("", []*parse.Directive{d})
+	if len(doc) > 0 {
+		directive = "//\n" + directive
+	}
+	return doc + directive + strings.Join(lines[i:], "")
+}
+
+func originPackage(origin string) string {
+	return origin[:strings.LastIndex(origin, ".")]
+}
+
+func originName(origin string) string {
+	return origin[strings.LastIndex(origin, ".")+1:]
+}
+
+// modelTypeOrigin 从类型的注释中读取来源, 本次生成中添加的类型没有语法树, 所以直接查找代码
+func modelTypeOrigin(tpe *parse.ModelType) string {
+	prefix := parse.DocWithDirectives("", []*parse.Directive{{Name: directiveFrom}})
+	prefix = strings.TrimSuffix(prefix, "\n") + " "
+	for _, line := range strings.Split(string(tpe.Content), "\n") {
+		if !strings.HasPrefix(line, "//") {
+			break
+		}
+		if strings.HasPrefix(line, prefix) {
+			return strings.TrimSpace(line[len(prefix):])
+		}
+	}
+	return ""
+}
+
+// exportedIdent 将包名转换为导出的标识符, e.g. user-api => UserApi
+func exportedIdent(s string) string {
+	var sb strings.Builder
+	upper := true
+	for _, r := range s {
+		if !unicode.IsLetter(r) && !unicode.IsDigit(r) {
+			upper = true
+			continue
+		}
+		if upper {
+			r = unicode.ToUpper(r)
+			upper = false
+		}
+		sb.WriteRune(r)
+	}
+	return sb.String()
+}
@@ -0,0 +1,93 @@
+package emit
+
+import (
+	"sr/parse"
+	"strings"
+	"testing"
+)
+
+func TestAllocateNames(t *testing.T) {
+	model, err := parse.ParseFileModel(`testdata/rename/model.go`)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	names, _ := allocateNames(model, []string{"abc/billing.Profile", "abc/user.Profile", "abc/x.Legacy", "abc/y/billing.Profile"}, nil, nil)
+	excepts := map[string]string{
+		// 沿用 model.go 中记录的名称
+		"abc/user.Profile":      "Profile",
+		"abc/billing.Profile":   "BillingProfile",
+		"abc/y/billing.Profile": "YBillingProfile",
+		// 没有记录来源的类型直接覆盖
+		"abc/x.Legacy": "Legacy",
+	}
+	for origin, except := range excepts {
+		if names[origin] != except {
+			t.Errorf("except %s named %s but got %s", origin, except, names[origin])
+			return
+		}
+	}
+}
+
+func TestAllocateConstNames(t *testing.T) {
+	model, err := parse.ParseFileModel(`testdata/rename/enum.go`)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	consts := map[string][]string{
+		"abc/user.Status":    {"Active", "Disabled"},
+		"abc/billing.Status": {"Active", "Overdue"},
+	}
+	names, constNames := allocateNames(model, []string{"abc/user.Status", "abc/billing.Status", "abc/user.Profile"}, consts, []string{"Profile", "RegisterProfile", "localProfile", "IProfile"})
+	excepts := map[string]string{
+		// 与生成的访问函数 Profile() 冲突
+		"abc/user.Profile":   "UserProfile",
+		"abc/billing.Status": "Status",
+		"abc/user.Status":    "UserStatus",
+	}
+	for origin, except := range excepts {
+		if names[origin] != except {
+			t.Errorf("except %s named %s but got %s", origin, except, names[origin])
+			return
+		}
+	}
+	constExcepts := map[string]string{
+		"abc/billing.Status.Active":  "Active",
+		"abc/billing.Status.Overdue": "Overdue",
+		"abc/user.Status.Active":     "UserActive",
+		"abc/user.Status.Disabled":   "Disabled",
+		// 沿用 model.go 中已有的常量不会被覆盖
+		"abc/billing.Status.Pending": "",
+	}
+	for key, except := range constExcepts {
+		origin, name := key[:strings.LastIndex(key, ".")], key[strings.LastIndex(key, ".")+1:]
+		if got := constNames[origin][name]; got != except {
+			t.Errorf("except constant %s named %s but got %s", key, except, got)
+			return
+		}
+	}
+	code := "type {{Status}} int\n\nconst (\n\tActive Status = iota\n\tDisabled\n)\n\nvar labels = map[Status]string{Active: \"active\"}\n\nfunc (s Status) IsActive() bool {\n\treturn s == Active\n}"
+	except := "type {{Status}} int\n\nconst (\n\tUserActive Status = iota\n\tDisabled\n)\n\nvar labels = map[Status]string{UserActive: \"active\"}\n\nfunc (s Status) IsActive() bool {\n\treturn s == UserActive\n}"
+	if got := renameConstIdents(code, constNames["abc/user.Status"]); got != except {
+		t.Errorf("except %s but got %s", except, got)
+		return
+	}
+	if got := constNamesInCode(code); strings.Join(got, ",") != "Active,Disabled" {
+		t.Errorf("except constants Active,Disabled but got %v", got)
+	}
+}
+
+func TestRenameTypeIdent(t *testing.T) {
+	code := "// Profile 资料\ntype Profile struct {\n\tProfile *Profile\n\tUser    *{{user}}\n}\n\nfunc (p Profile) Copy() Profile {\n\treturn Profile{Profile: p.Profile}\n}"
+	except := "// Profile 资料\ntype BillingProfile struct {\n\tProfile *BillingProfile\n\tUser    *{{user}}\n}\n\nfunc (p BillingProfile) Copy() BillingProfile {\n\treturn BillingProfile{Profile: p.Profile}\n}"
+	if got := renameTypeIdent(code, "Profile", "BillingProfile"); got != except {
+		t.Errorf("except %s but got %s", except, got)
+		return
+	}
+	except = "// doc\n//\n//sr:from abc/user.Profile\ntype Profile struct{}"
+	if got := insertDirective("// doc\ntype Profile struct{}", &parse.Directive{Name: directiveFrom, Args: "abc/user.Profile"}); got != except {
+		t.Errorf("except %s but got %s", except, got)
+		return
+	}
+}
@@ -2,13 +2,13 @@ package emit
 
 import (
 	"fmt"
+	"go/ast"
 	"go/token"
+	"path"
 	"regexp"
 	"sr/parse"
 	"sr/util"
-	"strings"
 
-	"github.com/aundis/meta"
 	"github.com/gogf/gf/v2/util/guid"
 )
 
@@ -22,119 +22,301 @@ type XXX struct {
 type typeResolver struct {
 	module   string
 	root     string
-	resolved map[string]*meta.TypeMeta
+	option   Option
+	resolved map[string]*TypeMeta
+	// 复制的第三方包, 目录 => 导入路径
+	vendored map[string]string
 }
 
-func (r *typeResolver) resolve(file *parse.File, compound string, pos token.Pos) (string, error) {
-	// 获取包路径
+func newTypeResolver(root, module string, option Option) *typeResolver {
+	return &typeResolver{
+		module:   module,
+		root:     root,
+		option:   option,
+		resolved: map[string]*TypeMeta{},
+		vendored: map[string]string{},
+	}
+}
+
+// resolve 将类型表达式中引用的自定义类型替换为 {{id}} 占位符, 并记录对应的 TypeMeta
+func (r *typeResolver) resolve(file *parse.File, expr ast.Expr) (string, error) {
+	return rewriteTypeRefs(file.Content, expr.Pos(), expr.End(), collectTypeRefs(expr), r.replaceRef(file))
+}
+
+func (r *typeResolver) replaceRef(file *parse.File) func(ref typeRef) (string, error) {
+	return func(ref typeRef) (string, error) {
+		loc, err := r.locate(file, ref)
+		if err != nil {
+			return "", err
+		}
+		// 内置类型的别名直接替换为内置类型
+		if loc.builtin {
+			return loc.name, nil
+		}
+		typeMeta, err := r.resolveRef(file, ref, loc)
+		if err != nil {
+			return "", err
+		}
+		return "{{" + typeMeta.Id + "}}", nil
+	}
+}
+
+// typeLocation 类型引用指向的声明
+type typeLocation struct {
+	pkgPath string
+	name    string
+	alias   string
+	// 声明所在的目录, 不在本项目中且未做类型检查时为空
+	dir     string
+	builtin bool
+}
+
+// locate 查找类型引用指向的声明, 类型检查模式下使用 go/types 的结果,
+// 复制的第三方包不在项目中, 只根据语法查找
+func (r *typeResolver) locate(file *parse.File, ref typeRef) (*typeLocation, error) {
+	if r.option.TypeCheck && !r.isVendoredFile(file) {
+		return r.locateChecked(file, ref)
+	}
+	return r.locateSyntax(file, ref)
+}
+
+// locateSyntax 只根据 import 声明查找引用指向的包
+func (r *typeResolver) locateSyntax(file *parse.File, ref typeRef) (*typeLocation, error) {
+	loc := &typeLocation{
+		name: ref.name,
+	}
+	if len(ref.scope) > 0 {
+		imp := resolveImport(file, ref.scope)
+		if imp == nil {
+			return nil, formatError(file.FileSet, ref.pos, "not found type scope "+ref.scope, r.root)
+		}
+		loc.pkgPath = imp.Path
+		loc.alias = imp.Name
+	} else {
+		// 获取包路径
+		var err error
+		loc.pkgPath, err = r.packagePath(file)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if isProjectPackage(r.module, loc.pkgPath) {
+		loc.dir = packagePathToFileName(r.root, loc.pkgPath)
+	} else if r.isVendored(loc.pkgPath) {
+		var err error
+		loc.dir, err = r.vendorDir(file, ref, loc.pkgPath)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return loc, nil
+}
+
+func (r *typeResolver) locateChecked(file *parse.File, ref typeRef) (*typeLocation, error) {
 	pkgPath, err := util.GetGoFilePackagePath(r.root, r.module, file.FileName)
 	if err != nil {
-		return "", err
+		return nil, err
 	}
-	var modelType *parse.ModelType
-	typeNames := getTypeNames(compound)
-	for _, typeName := range typeNames {
-		var typeMeta *meta.TypeMeta
-		if strings.Contains(typeName, ".") {
-			arr := strings.Split(typeName, ".")
-			scope := arr[0]
-			name := arr[1]
-			imp := resolveImport(file, scope)
-			if imp == nil {
-				return "", formatError(file.FileSet, pos, "not found type scope "+scope, r.root)
-			}
-			// 判断是否解析过了
-			if r.resolved[imp.Path+"@"+name] != nil {
-				typeMeta = r.resolved[imp.Path+"@"+name]
-			} else {
-				typeMeta = &meta.TypeMeta{
-					Id:   guid.S(),
-					Name: name,
-				}
-				// 本项目的类型才需要解析
-				if !isProjectPackage(r.module, imp.Path) {
-					typeMeta.Import = &meta.ImportMeta{
-						Path:  imp.Path,
-						Alias: imp.Name,
-					}
-				} else {
-					model, err := parse.ParsePackageModel(packagePathToFileName(r.root, imp.Path))
-					if err != nil {
-						return "", err
-					}
-					if !model.ContainsType(name) {
-						return "", formatError(file.FileSet, pos, fmt.Sprintf("package %s not found type %s", imp.Path, name), r.root)
-					}
-					modelType = model.GetType(name)
-					typeMeta.From = imp.Path
-					typeMeta.Code = string(modelType.Content)
-				}
-				r.resolved[imp.Path+"@"+name] = typeMeta
-			}
-		} else {
-			if r.resolved[pkgPath+"@"+typeName] != nil {
-				typeMeta = r.resolved[pkgPath+"@"+typeName]
-			} else {
-				model, err := parse.ParsePackageModel(packagePathToFileName(r.root, pkgPath))
-				if err != nil {
-					return "", err
-				}
-				if !model.ContainsType(typeName) {
-					return "", formatError(file.FileSet, pos, fmt.Sprintf("package %s not found type %s", pkgPath, typeName), r.root)
-				}
-				modelType = model.GetType(typeName)
-				typeMeta = &meta.TypeMeta{
-					Id:     guid.S(),
-					Name:   typeName,
-					From:   pkgPath,
-					Code:   string(modelType.Content),
-					Import: nil,
-				}
-				r.resolved[pkgPath+"@"+typeName] = typeMeta
-			}
+	if r.option.checker == nil {
+		r.option.checker = parse.NewChecker(r.option.Tags...)
+	}
+	pkg, err := r.option.checker.CheckPackage(path.Dir(file.FileName), pkgPath)
+	if err != nil {
+		return nil, err
+	}
+	obj, err := pkg.LookupType(file, ref.expr)
+	if err != nil {
+		return nil, formatError(file.FileSet, ref.pos, err.Error(), r.root)
+	}
+	if obj.Pkg() == nil {
+		return &typeLocation{name: obj.Name(), builtin: true}, nil
+	}
+	loc := &typeLocation{
+		pkgPath: obj.Pkg().Path(),
+		name:    obj.Name(),
+		dir:     pkg.DeclDir(obj),
+	}
+	// 保留导入时指定的别名
+	for _, imp := range file.Imports {
+		if imp.Path == loc.pkgPath && imp.Name != "." && imp.Name != "_" {
+			loc.alias = imp.Name
 		}
-		if modelType != nil && parse.IsStructType(modelType.Raw) {
-			fields := getStructInnerFields(modelType.Raw)
-			structType := modelType.Raw.(*parse.StructType)
-			for _, field := range fields {
-				fieldType := field.Type
-				if !hasCustomerType(fieldType) {
-					continue
-				}
-				template, err := r.resolve(structType.Parent, field.Type, field.Pos)
-				if err != nil {
-					return "", err
-				}
-				// 优化替换, 防止出现名称和类型同名的情况
-				reg := regexp.MustCompile(fmt.Sprintf(`\b%s\s+%s\b`, field.Name, regexp.QuoteMeta(field.Type)))
-				typeMeta.Code = reg.ReplaceAllString(typeMeta.Code, fmt.Sprintf("%s %s", field.Name, template))
-			}
+	}
+	if r.isVendored(loc.pkgPath) {
+		loc.dir, err = r.vendorDir(file, ref, loc.pkgPath)
+		if err != nil {
+			return nil, err
 		}
-		reg := regexp.MustCompile(fmt.Sprintf(`\b%s\b`, regexp.QuoteMeta(typeName)))
-		compound = reg.ReplaceAllString(compound, fmt.Sprintf("{{%s}}", typeMeta.Id))
 	}
-	return compound, nil
+	return loc, nil
+}
+
+func (r *typeResolver) resolveRef(file *parse.File, ref typeRef, loc *typeLocation) (*TypeMeta, error) {
+	// 判断是否解析过了
+	key := loc.pkgPath + "@" + loc.name
+	if r.resolved[key] != nil {
+		return r.resolved[key], nil
+	}
+	typeMeta := &TypeMeta{
+		Id:   guid.S(),
+		Name: loc.name,
+	}
+	// 本项目和复制的第三方包的类型才需要解析
+	if !r.isCopied(loc.pkgPath) {
+		typeMeta.Import = &ImportMeta{
+			Path:  loc.pkgPath,
+			Alias: loc.alias,
+		}
+		r.resolved[key] = typeMeta
+		return typeMeta, nil
+	}
+	model, err := parse.ParsePackageModel(loc.dir, r.option.Tags...)
+	if err != nil {
+		return nil, err
+	}
+	if !model.ContainsType(loc.name) {
+		return nil, formatError(file.FileSet, ref.pos, fmt.Sprintf("package %s not found type %s", loc.pkgPath, loc.name), r.root)
+	}
+	typeMeta.From = loc.pkgPath
+	// 先登记再解析类型的代码, 类型之间相互引用时才能终止
+	r.resolved[key] = typeMeta
+	modelType := model.GetType(loc.name)
+	typeMeta.Code, err = r.resolveTypeCode(modelType)
+	if err != nil {
+		return nil, err
+	}
+	typeMeta.Values = resolveTypeValues(modelType)
+	return typeMeta, nil
 }
 
-func (r *typeResolver) getTypeMetas() []*meta.TypeMeta {
-	var result []*meta.TypeMeta
+// expandInterface 展开接口中嵌入的其他文件或其他包的接口, 把它们的方法合并进来
+func (r *typeResolver) expandInterface(it *parse.InterfaceType) error {
+	return r.doExpandInterface(it, map[*parse.InterfaceType]bool{})
+}
+
+func (r *typeResolver) doExpandInterface(it *parse.InterfaceType, visiting map[*parse.InterfaceType]bool) error {
+	if visiting[it] {
+		return nil
+	}
+	visiting[it] = true
+	embeds := it.Embeds
+	it.Embeds = nil
+	for _, embed := range embeds {
+		target, err := r.lookupInterface(embed)
+		if err != nil {
+			return err
+		}
+		err = r.doExpandInterface(target, visiting)
+		if err != nil {
+			return err
+		}
+		it.Functions = parse.MergeFunctions(it.Functions, target.Functions)
+	}
+	return nil
+}
+
+func (r *typeResolver) lookupInterface(embed *parse.Field) (*parse.InterfaceType, error) {
+	file := embed.Parent
+	refs := collectTypeRefs(fieldTypeExpr(embed.TypeRaw))
+	if len(refs) != 1 {
+		return nil, formatError(file.FileSet, embed.Pos, "unsupported embedded type "+embed.Type, r.root)
+	}
+	loc, err := r.locate(file, refs[0])
+	if err != nil {
+		return nil, err
+	}
+	if loc.builtin || len(loc.dir) == 0 {
+		return nil, formatError(file.FileSet, embed.Pos, "cannot resolve embedded interface "+embed.Type+" without type check", r.root)
+	}
+	model, err := parse.ParsePackageModel(loc.dir, r.option.Tags...)
+	if err != nil {
+		return nil, err
+	}
+	if !model.ContainsType(loc.name) {
+		return nil, formatError(file.FileSet, embed.Pos, fmt.Sprintf("package %s not found type %s", loc.pkgPath, loc.name), r.root)
+	}
+	it, ok := model.GetType(loc.name).Raw.(*parse.InterfaceType)
+	if !ok {
+		return nil, formatError(file.FileSet, embed.Pos, embed.Type+" is not an interface", r.root)
+	}
+	return it, nil
+}
+
+// resolveTypeCode 生成类型声明的代码, 其中引用的自定义类型同样替换为占位符
+func (r *typeResolver) resolveTypeCode(modelType *parse.ModelType) (string, error) {
+	var file *parse.File
+	var expr ast.Expr
+	var params *ast.FieldList
+	var pos, end token.Pos
+	var doc string
+	switch n := modelType.Raw.(type) {
+	case *parse.StructType:
+		file, expr, params, pos, end, doc = n.Parent, fieldTypeExpr(n.TypeRaw), typeParamList(n.TypeParams), n.Pos, n.End, n.Doc
+	case *parse.InterfaceType:
+		file, expr, params, pos, end, doc = n.Parent, fieldTypeExpr(n.TypeRaw), typeParamList(n.TypeParams), n.Pos, n.End, n.Doc
+	case *parse.NamedType:
+		file, expr, params, pos, end, doc = n.Parent, fieldTypeExpr(n.TypeRaw), typeParamList(n.TypeParams), n.Pos, n.End, n.Doc
+	}
+	if file == nil || expr == nil {
+		return string(modelType.Content), nil
+	}
+	code, err := rewriteTypeRefs(file.Content, pos, end, collectDeclTypeRefs(params, expr), r.replaceRef(file))
+	if err != nil {
+		return "", err
+	}
+	// 字段的注释包含在源码中, 类型的注释需要单独加上
+	code = parse.DocComment(doc) + "type " + code
+	// 枚举类型携带其常量和方法, 开启 Option.Methods 时其他类型同样携带方法
+	if _, ok := modelType.Raw.(*parse.NamedType); ok || r.option.Methods {
+		carried, err := r.carryTypeDecls(modelType)
+		if err != nil {
+			return "", err
+		}
+		code += carried
+	}
+	return code, nil
+}
+
+func (r *typeResolver) getTypeMetas() []*TypeMeta {
+	var result []*TypeMeta
 	for _, v := range r.resolved {
 		result = append(result, v)
 	}
 	return result
 }
 
-func newFieldResolver(root, module, exportTo string) *fieldResolver {
+// typeMetasOf 模板直接或间接引用的类型, 按引用出现的顺序排列, 每个类型只出现一次,
+// 相互引用的类型同样可以终止
+func (r *typeResolver) typeMetasOf(template string) []*TypeMeta {
+	all := r.getTypeMetas()
+	var result []*TypeMeta
+	visited := map[string]bool{}
+	queue := findAllTypeMetaIds(template)
+	for len(queue) > 0 {
+		id := queue[0]
+		queue = queue[1:]
+		if visited[id] {
+			continue
+		}
+		visited[id] = true
+		tmeta := findTypeMetaForId(all, id)
+		if tmeta == nil {
+			continue
+		}
+		result = append(result, tmeta)
+		queue = append(queue, findAllTypeMetaIds(tmeta.Code)...)
+	}
+	return result
+}
+
+func newFieldResolver(root, module, exportTo string, imports *importCollect, option Option) *fieldResolver {
 	return &fieldResolver{
-		module:   module,
-		root:     root,
-		exportTo: exportTo,
-		tResolver: &typeResolver{
-			module:   module,
-			root:     root,
-			resolved: map[string]*meta.TypeMeta{},
-		},
-		resolved: make(map[*parse.Field]string),
+		module:    module,
+		root:      root,
+		exportTo:  exportTo,
+		imports:   imports,
+		tResolver: newTypeResolver(root, module, option),
+		resolved:  make(map[*parse.Field]string),
 	}
 }
 
@@ -142,17 +324,19 @@ type fieldResolver struct {
 	module    string
 	root      string
 	exportTo  string
+	imports   *importCollect
 	tResolver *typeResolver
 	resolved  map[*parse.Field]string
 }
 
 func (r *fieldResolver) resolve(field *parse.Field) error {
-	if !hasCustomerType(field.Type) {
+	expr := fieldTypeExpr(field.TypeRaw)
+	if expr == nil || !hasCustomType(expr) {
 		r.resolved[field] = field.Type
 		return nil
 	}
 	tResolver := r.tResolver
-	template, err := tResolver.resolve(field.Parent, field.Type, field.Pos)
+	template, err := tResolver.resolve(field.Parent, expr)
 	if err != nil {
 		return err
 	}
@@ -164,6 +348,7 @@ func (r *fieldResolver) resolve(field *parse.Field) error {
 			return findTypeMetaForId(tmetas, tmetaId).From
 		},
 		currentPackage: r.exportTo,
+		imports:        r.imports,
 	}), nil
 	r.resolved[field] = real
 	return nil
@@ -177,7 +362,7 @@ func (r *fieldResolver) getResolvedType(field *parse.Field) string {
 // 	resolver := &typeResolver{
 // 		module:   module,
 // 		root:     root,
-// 		resolved: map[string]*meta.TypeMeta{},
+// 		resolved: map[string]*TypeMeta{},
 // 	}
 // 	for _, field := range fields {
 // 		if !hasCustomerType(field.Type) {
@@ -202,9 +387,11 @@ func (r *fieldResolver) getResolvedType(field *parse.Field) string {
 
 type replacePseudocodePartInput struct {
 	content        string
-	tmetas         []*meta.TypeMeta
+	tmetas         []*TypeMeta
 	getExportTo    func(string) string
 	currentPackage string
+	// 引用其他包的类型时通过 imports 导入, 使用分配的名称
+	imports *importCollect
 }
 
 var regPseudocode = regexp.MustCompile(`\{\{.+?\}\}`)
@@ -214,10 +401,10 @@ func replacePseudocodePart(in replacePseudocodePartInput) string {
 		id := s[2 : len(s)-2]
 		tmeta := findTypeMetaForId(in.tmetas, id)
 		if tmeta.Import != nil {
-			return getImportMetaExport(tmeta.Import) + "." + tmeta.Name
+			return in.imports.Add(tmeta.Import.Path) + "." + tmeta.Name
 		} else {
 			if in.getExportTo(tmeta.Id) != in.currentPackage {
-				return getImportPathExport(in.getExportTo(tmeta.Id)) + "." + tmeta.Name
+				return in.imports.Add(in.getExportTo(tmeta.Id)) + "." + tmeta.Name
 			} else {
 				return tmeta.Name
 			}
@@ -225,60 +412,7 @@ func replacePseudocodePart(in replacePseudocodePartInput) string {
 	})
 }
 
-func hasCustomerType(in string) bool {
-	arr := getTypeNames(in)
-	for _, v := range arr {
-		if !isBuiltin(v) {
-			return true
-		}
-	}
-	return false
-}
-
-func isBuiltin(in string) bool {
-	builtin := []string{
-		"int",
-		"int8",
-		"int16",
-		"int32",
-		"int64",
-		"uint",
-		"uint8",
-		"uint16",
-		"uint32",
-		"uint64",
-		"bool",
-		"interface",
-		"any",
-		"map",
-		"byte",
-		"rune",
-		"string",
-	}
-	for _, v := range builtin {
-		if v == in {
-			return true
-		}
-	}
-	return false
-}
-
-func getTypeNames(compound string) []string {
-	var results []string
-	reg := regexp.MustCompile(`\b([\w\.]+)\b`)
-	matchs := reg.FindAllStringSubmatch(compound, -1)
-	for _, v := range matchs {
-		tar := v[1]
-		if strings.Contains(tar, ".") {
-			results = append(results, tar)
-		} else if tar[0] >= 'A' && tar[0] <= 'Z' {
-			results = append(results, tar)
-		}
-	}
-	return results
-}
-
-func findTypeMetaForId(arr []*meta.TypeMeta, id string) *meta.TypeMeta {
+func findTypeMetaForId(arr []*TypeMeta, id string) *TypeMeta {
 	for _, v := range arr {
 		if v.Id == id {
 			return v
@@ -2,23 +2,23 @@ package emit
 
 import (
 	"sr/parse"
+	"strings"
 	"testing"
-
-	"github.com/aundis/meta"
 )
 
 func TestResolver(t *testing.T) {
 	resolver := &typeResolver{
 		module:   "abc",
 		root:     `testdata/resolver`,
-		resolved: map[string]*meta.TypeMeta{},
+		resolved: map[string]*TypeMeta{},
 	}
 	file, err := parse.ParseFile(`testdata/resolver/model1/model.go`)
 	if err != nil {
 		t.Error(err)
 		return
 	}
-	template, err := resolver.resolve(file, "model2.M2", 0)
+	field := file.StructTypes[0].Fields[0]
+	template, err := resolver.resolve(file, fieldTypeExpr(field.TypeRaw))
 	if err != nil {
 		t.Error(err)
 		return
@@ -33,3 +33,288 @@ func TestResolver(t *testing.T) {
 		return
 	}
 }
+
+func TestResolverCompoundTypes(t *testing.T) {
+	resolver := &typeResolver{
+		module:   "abc",
+		root:     `testdata/resolver`,
+		resolved: map[string]*TypeMeta{},
+	}
+	file, err := parse.ParseFile(`testdata/resolver/model4/model.go`)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	var m4 *parse.StructType
+	for _, st := range file.StructTypes {
+		if st.Name == "M4" {
+			m4 = st
+		}
+	}
+	for _, field := range m4.Fields {
+		template, err := resolver.resolve(file, fieldTypeExpr(field.TypeRaw))
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		if strings.Contains(template, "model2.") || template == "item" || template == "wrapper" {
+			t.Errorf("except field %s type resolved, but got %s", field.Name, template)
+			return
+		}
+	}
+	// M2, M3, M1, context.Context, item, wrapper
+	list := resolver.getTypeMetas()
+	if len(list) != 6 {
+		t.Errorf("except type metas count = 6, bug got %d", len(list))
+		return
+	}
+	for _, tmeta := range list {
+		if tmeta.Name == "item" && !strings.Contains(tmeta.Code, "Name string") {
+			t.Errorf("except item code copied, but got %s", tmeta.Code)
+			return
+		}
+		// 嵌入字段和匿名结构体中的类型同样需要替换
+		if tmeta.Name == "wrapper" && (strings.Contains(tmeta.Code, "model2.") || strings.Contains(tmeta.Code, "[]item")) {
+			t.Errorf("except wrapper code resolved, but got %s", tmeta.Code)
+			return
+		}
+	}
+}
+
+func TestResolverTypeCheck(t *testing.T) {
+	resolver := newTypeResolver(`testdata/checked`, "abc", Option{TypeCheck: true})
+	file, err := parse.ParseFile(`testdata/checked/api/api.go`)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	var templates []string
+	for _, field := range file.StructTypes[0].Fields {
+		template, err := resolver.resolve(file, fieldTypeExpr(field.TypeRaw))
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		templates = append(templates, template)
+	}
+	// 别名和点导入都指向 model.User
+	if templates[0] != templates[1] {
+		t.Errorf("except alias and dot import resolved to same type, but got %s and %s", templates[0], templates[1])
+		return
+	}
+	if templates[2] != "int" {
+		t.Errorf("except builtin alias resolved to int, but got %s", templates[2])
+		return
+	}
+	list := resolver.getTypeMetas()
+	if len(list) != 1 || list[0].From != "abc/model" {
+		t.Errorf("except type metas only contains abc/model.User, bug got %d", len(list))
+		return
+	}
+}
+
+func TestResolverNamedType(t *testing.T) {
+	resolver := newTypeResolver(`testdata/resolver`, "abc", Option{})
+	file, err := parse.ParseFile(`testdata/resolver/model5/model.go`)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	field := file.StructTypes[0].Fields[0]
+	_, err = resolver.resolve(file, fieldTypeExpr(field.TypeRaw))
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	// Status, fmt.Sprintf
+	list := resolver.getTypeMetas()
+	if len(list) != 2 {
+		t.Errorf("except type metas count = 2, bug got %d", len(list))
+		return
+	}
+	for _, tmeta := range list {
+		if tmeta.Name != "Status" {
+			continue
+		}
+		if !strings.Contains(tmeta.Code, "StatusPaid") || !strings.Contains(tmeta.Code, "String() string") {
+			t.Errorf("except constants and String method copied, but got %s", tmeta.Code)
+			return
+		}
+		if !strings.HasPrefix(tmeta.Code, "// Status 订单状态\n") || !strings.Contains(tmeta.Code, "// String 状态名称\nfunc") {
+			t.Errorf("except doc comments copied, but got %s", tmeta.Code)
+			return
+		}
+		// Label 引用了包级别的变量, 不能复制
+		if strings.Contains(tmeta.Code, "Label") {
+			t.Errorf("except Label method ignored, but got %s", tmeta.Code)
+			return
+		}
+		if len(tmeta.Values) != 2 || tmeta.Values[1].Name != "StatusPaid" || tmeta.Values[1].Value != "2" {
+			t.Errorf("except values StatusPending=1, StatusPaid=2, but got %d values", len(tmeta.Values))
+			return
+		}
+	}
+}
+
+func TestResolverRecursiveTypes(t *testing.T) {
+	resolver := newTypeResolver(`testdata/resolver`, "abc", Option{})
+	file, err := parse.ParseFile(`testdata/resolver/model7/model.go`)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	field := file.StructTypes[0].Fields[1]
+	template, err := resolver.resolve(file, fieldTypeExpr(field.TypeRaw))
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	// Order, Customer, Node
+	list := resolver.typeMetasOf(template)
+	if len(list) != 3 {
+		t.Errorf("except type metas count = 3, bug got %d", len(list))
+		return
+	}
+	if list[0].Name != "Order" {
+		t.Errorf("except first type meta Order, but got %s", list[0].Name)
+		return
+	}
+	for _, tmeta := range list {
+		switch tmeta.Name {
+		case "Node":
+			// 引用自身
+			if strings.Count(tmeta.Code, "{{"+tmeta.Id+"}}") != 2 {
+				t.Errorf("except Node references itself, but got %s", tmeta.Code)
+				return
+			}
+		case "Customer":
+			// 引用另一个包中引用了自己的类型
+			if !strings.Contains(tmeta.Code, "[]{{"+list[0].Id+"}}") {
+				t.Errorf("except Customer references Order, but got %s", tmeta.Code)
+				return
+			}
+		}
+	}
+	// 再次解析时沿用已有的类型
+	again, err := resolver.resolve(file, fieldTypeExpr(field.TypeRaw))
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	if again != template || len(resolver.getTypeMetas()) != 3 {
+		t.Errorf("except template %s, but got %s", template, again)
+	}
+}
+
+func TestResolverVendor(t *testing.T) {
+	file, err := parse.ParseFile(`testdata/vendor/model/model.go`)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	for _, option := range []Option{{}, {Vendor: []string{"example.com/dto/..."}}, {Vendor: []string{"example.com/dto"}, TypeCheck: true}} {
+		resolver := newTypeResolver(`testdata/vendor`, "abc", option)
+		var templates []string
+		for _, field := range file.StructTypes[0].Fields {
+			template, err := resolver.resolve(file, fieldTypeExpr(field.TypeRaw))
+			if err != nil {
+				t.Error(err)
+				return
+			}
+			templates = append(templates, template)
+		}
+		list := resolver.typeMetasOf(strings.Join(templates, " "))
+		if len(option.Vendor) == 0 {
+			// dto.Money, time.Time
+			if len(list) != 2 || list[0].Import == nil || list[0].Import.Path != "example.com/dto" {
+				t.Errorf("except example.com/dto imported, but got %d type metas", len(list))
+			}
+			continue
+		}
+		// Money, time.Time, Currency, 标准库总是导入
+		if len(list) != 3 || list[1].Import == nil || list[1].Import.Path != "time" {
+			t.Errorf("except Money, time.Time and Currency, but got %d type metas", len(list))
+			return
+		}
+		money, currency := list[0], list[2]
+		if money.Import != nil || money.From != "example.com/dto" || !strings.Contains(money.Code, "Currency {{"+currency.Id+"}}") {
+			t.Errorf("except Money copied, but got %s", money.Code)
+			return
+		}
+		if currency.Name != "Currency" || !strings.Contains(currency.Code, "CurrencyUSD") {
+			t.Errorf("except Currency copied with constants, but got %s", currency.Code)
+			return
+		}
+	}
+}
+
+func TestMatchPackagePattern(t *testing.T) {
+	cases := []struct {
+		pattern string
+		path    string
+		match   bool
+	}{
+		{"example.com/dto", "example.com/dto", true},
+		{"example.com/dto", "example.com/dto/v2", false},
+		{"example.com/dto/...", "example.com/dto", true},
+		{"example.com/dto/...", "example.com/dto/v2", true},
+		{"example.com/dto/...", "example.com/dtox", false},
+		{"...", "example.com/dto", true},
+	}
+	for _, c := range cases {
+		if matchPackagePattern(c.pattern, c.path) != c.match {
+			t.Errorf("except pattern %s match %s = %v", c.pattern, c.path, c.match)
+		}
+	}
+}
+
+func TestResolverCarryMethods(t *testing.T) {
+	file, err := parse.ParseFile(`testdata/resolver/model8/model.go`)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	field := file.StructTypes[0].Fields[0]
+	for _, option := range []Option{{}, {Methods: true}} {
+		resolver := newTypeResolver(`testdata/resolver`, "abc", option)
+		template, err := resolver.resolve(file, fieldTypeExpr(field.TypeRaw))
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		account := resolver.typeMetasOf(template)[0]
+		if !option.Methods {
+			if strings.Contains(account.Code, "func ") {
+				t.Errorf("except no methods copied, but got %s", account.Code)
+			}
+			continue
+		}
+		for _, method := range []string{"Validate", "checkEmail", "MarshalJSON"} {
+			if !strings.Contains(account.Code, ") "+method+"(") {
+				t.Errorf("except method %s copied, but got %s", method, account.Code)
+				return
+			}
+		}
+		// 引用了包级别的变量, 调用了无法复制的方法和引用了第三方包的方法不能复制
+		for _, method := range []string{"Normalize", "Display", "Tag"} {
+			if strings.Contains(account.Code, ") "+method+"(") {
+				t.Errorf("except method %s ignored, but got %s", method, account.Code)
+				return
+			}
+		}
+		if !strings.Contains(account.Code, "// Validate 校验账户\nfunc") {
+			t.Errorf("except doc comments copied, but got %s", account.Code)
+			return
+		}
+		// 接收者中声明的类型参数
+		template, err = resolver.resolve(file, fieldTypeExpr(file.StructTypes[0].Fields[1].TypeRaw))
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		list := resolver.typeMetasOf(template)[0]
+		if !strings.Contains(list.Code, "func (l *List[T]) Len() int") {
+			t.Errorf("except method Len copied, but got %s", list.Code)
+		}
+	}
+}
@@ -8,7 +8,7 @@ import (
 	"strconv"
 )
 
-func EmitSignal(root string) error {
+func EmitSignal(root string, option ...Option) error {
 	// 取项目模块名
 	module, err := getProjectModuleName(root)
 	if err != nil {
@@ -18,7 +18,9 @@ func EmitSignal(root string) error {
 		root:     root,
 		module:   module,
 		exportTo: fmt.Sprintf("%s/internal/srpc/emit", module),
+		option:   firstOption(option),
 		writer:   util.NewTextWriter(),
+		smap:     util.NewSourceMap(),
 	}
 	err = emiter.emit()
 	if err != nil {
@@ -47,7 +49,10 @@ type signalEmiter struct {
 	root     string
 	module   string
 	exportTo string
+	option   Option
+	imports  *importCollect
 	writer   util.TextWriter
+	smap     *util.SourceMap
 }
 
 func (e *signalEmiter) emit() error {
@@ -62,28 +67,44 @@ func (e *signalEmiter) emit() error {
 		return err
 	}
 	// 获取待处理的Go文件
-	files, err := listFile(dir)
+	goFiles, err := parse.ListGoFiles(dir, e.option.Tags...)
 	if err != nil {
 		return err
 	}
-	var goFiles []string
-	for _, v := range files {
-		if path.Ext(v) == ".go" {
-			goFiles = append(goFiles, v)
-		}
-	}
 	if len(goFiles) == 0 {
 		return nil
 	}
 	// 获取所有需要处理的接口类型
-	var interfaceTypes []*parse.InterfaceType
+	var astFiles []*parse.File
 	for _, filename := range goFiles {
 		astFile, err := parse.ParseFile(filename)
 		if err != nil {
 			return err
 		}
-		for _, it := range astFile.InterfaceTypes {
-			interfaceTypes = append(interfaceTypes, it)
+		astFiles = append(astFiles, astFile)
+	}
+	resolver := newTypeResolver(e.root, e.module, e.option)
+	checker := newWireChecker(e.root, e.module, e.option)
+	interfaceTypes := parse.CombineInterfaceTypes(astFiles)
+	for _, it := range interfaceTypes {
+		// 展开其他包中的嵌入接口
+		err = resolver.expandInterface(it)
+		if err != nil {
+			return err
+		}
+		err = checkDirectives(it.Functions, e.root)
+		if err != nil {
+			return err
+		}
+		// 检查参数能否通过 json 传输
+		for _, fun := range it.Functions {
+			if isIgnored(fun) {
+				continue
+			}
+			err = checker.checkFunction(it.Name[1:], fun)
+			if err != nil {
+				return err
+			}
 		}
 	}
 	// 内容不生成文件
@@ -94,21 +115,21 @@ func (e *signalEmiter) emit() error {
 	writer := e.writer
 	writer.WriteString(generatedHeader).WriteLine()
 	writer.WriteString("package emit").WriteLine()
-	collect := newImportCollect()
+	collect := newImportCollect(e.root)
 	collect.Set("context", "context")
 	collect.Set("json", "encoding/json")
 	collect.Set("srpc", "github.com/aundis/srpc")
-	collect.Set("meta", "github.com/aundis/meta")
 	collect.Set("service", e.module+"/internal/service")
 	collect.Set("manager", e.module+"/internal/srpc/manager")
 	for _, it := range interfaceTypes {
-		err = resolveInterfaceImports(it, collect, e.exportTo, e.module, e.root)
+		err = resolveInterfaceImports(it, collect, e.exportTo, e.module, e.root, e.option)
 		if err != nil {
 			return err
 		}
 	}
 	writer.WriteEmptyLine()
 	collect.Emit(e.writer)
+	e.imports = collect
 	// 生成代码内容
 	for _, it := range interfaceTypes {
 		err = e.emitSignalInterface("main", it)
@@ -120,10 +141,12 @@ func (e *signalEmiter) emit() error {
 	writer.WriteEmptyLine()
 	writer.WriteString("func init() {").WriteLine().IncreaseIndent()
 	for _, it := range interfaceTypes {
-		err = emitSignalHelper(e.root, e.module, writer, it)
+		start := writer.Line()
+		err = emitSignalHelper(e.root, e.module, writer, it, e.option)
 		if err != nil {
 			return err
 		}
+		addSourceMapping(e.smap, writer, start, it.Parent.FileSet, it.Pos, it.End, it.Name, e.root)
 	}
 	writer.DecreaseIndent().WriteString("}").WriteLine()
 	// 写出文件
@@ -132,6 +155,10 @@ func (e *signalEmiter) emit() error {
 	if err != nil {
 		return err
 	}
+	err = util.WriteSourceMap(outPath, e.smap)
+	if err != nil {
+		return err
+	}
 	return nil
 }
 
@@ -143,39 +170,50 @@ func (e *signalEmiter) emitSignalInterface(target string, it *parse.InterfaceTyp
 		return formatError(it.Parent.FileSet, it.Pos, "interface name must start with an \"I\"", e.root)
 	}
 	structName := "c" + it.Name[1:]
+	// 参数和返回值的类型使用分配的包名
+	fResolver := newFieldResolver(e.root, e.module, e.exportTo, e.imports, e.option)
+	for _, v := range getInterfaceFields(it) {
+		err := fResolver.resolve(v)
+		if err != nil {
+			return err
+		}
+	}
 	writer.WriteEmptyLine()
+	start := writer.Line()
 	writer.WriteString("type ", structName, " struct {}").WriteLine()
 	// 写出变量
 	writer.WriteEmptyLine()
 	writer.WriteString("var ", firstUpper(it.Name[1:]), " ", it.Name, " = ", "&"+structName+"{}").WriteLine()
+	addSourceMapping(e.smap, writer, start, it.Parent.FileSet, it.Pos, it.End, it.Name, e.root)
 	for _, fun := range it.Functions {
 		// 先生成返回类型的结构体, 如果有返回值的话
 		responseStructName := firstLower(fun.Name) + "Response"
+		writer.WriteEmptyLine()
+		start := writer.Line()
 		if len(fun.Results) > 1 {
-			writer.WriteEmptyLine()
 			writer.WriteString("type ", responseStructName, " struct {").WriteLine().IncreaseIndent()
 			for i, r := range fun.Results {
 				if i == len(fun.Results)-1 {
 					continue
 				}
 				name := "r" + strconv.Itoa(i+1)
-				writer.WriteString(firstUpper(name), " ", r.Type, " `json:\"", name, "\"`").WriteLine()
+				writer.WriteString(firstUpper(name), " ", fResolver.getResolvedType(r), " `json:\"", name, "\"`").WriteLine()
 			}
 			writer.DecreaseIndent().WriteString("}").WriteLine()
+			writer.WriteEmptyLine()
 		}
 
 		// writer.WriteString(fmt.Sprintf("func (c *%s) %s (", structName, m.Name))
-		writer.WriteEmptyLine()
 		writer.WriteString("func (c *", structName, ") ", fun.Name, " (")
 		// 写参数
 		for i, p := range fun.Params {
 			// 首参数校验
 			if i == 0 {
 				if p.Name != "ctx" {
-					return formatError(it.Parent.FileSet, p.Pos, "first param name must is ctx", e.root)
+					return formatError(fun.Parent.FileSet, p.Pos, "first param name must is ctx", e.root)
 				}
 				if p.Type != "context.Context" {
-					return formatError(it.Parent.FileSet, p.Pos, "first param type must is context.Context", e.root)
+					return formatError(fun.Parent.FileSet, p.Pos, "first param type must is context.Context", e.root)
 				}
 			}
 			if i != 0 {
@@ -187,22 +225,22 @@ func (e *signalEmiter) emitSignalInterface(target string, it *parse.InterfaceTyp
 				name := "p" + strconv.Itoa(i)
 				writer.WriteString(name)
 			}
-			writer.WriteString(" ", p.Type)
+			writer.WriteString(" ", fResolver.getResolvedType(p))
 		}
 		writer.WriteString(")")
 		// 写返回值
 		if len(fun.Results) == 0 {
-			return formatError(it.Parent.FileSet, fun.Pos, "method must provide a return value of type error", e.root)
+			return formatError(fun.Parent.FileSet, fun.Pos, "method must provide a return value of type error", e.root)
 		}
 		if len(fun.Results) > 1 {
-			return formatError(it.Parent.FileSet, fun.Pos, "signal method can only have one return value", e.root)
+			return formatError(fun.Parent.FileSet, fun.Pos, "signal method can only have one return value", e.root)
 		}
 		writer.WriteString(" (")
 		for i, r := range fun.Results {
 			// 校验最后一个返回类型
 			if i == len(fun.Results)-1 {
 				if r.Type != "error" {
-					return formatError(it.Parent.FileSet, fun.Pos, "method last return value must be error", e.root)
+					return formatError(fun.Parent.FileSet, fun.Pos, "method last return value must be error", e.root)
 				}
 			}
 			if i != 0 {
@@ -214,9 +252,16 @@ func (e *signalEmiter) emitSignalInterface(target string, it *parse.InterfaceTyp
 			} else {
 				writer.WriteString("r" + strconv.Itoa(i+1))
 			}
-			writer.WriteString(" ", r.Type)
+			writer.WriteString(" ", fResolver.getResolvedType(r))
 		}
 		writer.WriteString(")", " {").WriteLine().IncreaseIndent()
+		// 忽略的方法只用于实现接口, 不发出信号
+		if isIgnored(fun) {
+			writer.WriteString("return").WriteLine()
+			writer.DecreaseIndent().WriteString("}").WriteLine()
+			addSourceMapping(e.smap, writer, start, fun.Parent.FileSet, fun.Pos, fun.End, it.Name+"."+fun.Name, e.root)
+			continue
+		}
 		// 方法体内容
 		// 	data, err := gjson.Marshal(g.Map{
 		// 		"a": a,
@@ -224,12 +269,14 @@ func (e *signalEmiter) emitSignalInterface(target string, it *parse.InterfaceTyp
 		// 	})
 		writer.WriteString("data, err := json.Marshal(map[string]interface{}{").WriteLine().IncreaseIndent()
 		if len(fun.Params) > 1 {
-			for i := range fun.Params {
+			for i, p := range fun.Params {
 				if i == 0 {
 					continue
 				}
 				name := "p" + strconv.Itoa(i)
-				writer.WriteString(`"`, name, `": `, name, ",").WriteLine()
+				for _, key := range sendKeys(e.option, p, "p", i) {
+					writer.WriteString(`"`, key, `": `, name, ",").WriteLine()
+				}
 			}
 		}
 		writer.DecreaseIndent().WriteString("})").WriteLine()
@@ -256,7 +303,7 @@ func (e *signalEmiter) emitSignalInterface(target string, it *parse.InterfaceTyp
 		writer.WriteString("service.Srpc().Request(ctx, srpc.RequestData {").IncreaseIndent().WriteLine()
 		writer.WriteString("Mark: srpc.EmitMark,").WriteLine()
 		writer.WriteString(`Target: "` + target + `",`).WriteLine()
-		writer.WriteString(`Action: "`, it.Name[1:], ".", fun.Name, `",`).WriteLine()
+		writer.WriteString(`Action: "`, it.Name[1:], ".", actionName(fun), `",`).WriteLine()
 		writer.WriteString("Data:   data,").WriteLine()
 		writer.DecreaseIndent().WriteString("})").WriteLine()
 		writer.WriteString("if err != nil {").WriteLine().IncreaseIndent()
@@ -284,6 +331,7 @@ func (e *signalEmiter) emitSignalInterface(target string, it *parse.InterfaceTyp
 		}
 		writer.WriteString("return").WriteLine()
 		writer.DecreaseIndent().WriteString("}").WriteLine()
+		addSourceMapping(e.smap, writer, start, fun.Parent.FileSet, fun.Pos, fun.End, it.Name+"."+fun.Name, e.root)
 	}
 	return nil
 }
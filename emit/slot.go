@@ -1,6 +1,7 @@
 package emit
 
 import (
+	"errors"
 	"fmt"
 	"path"
 	"sr/parse"
@@ -9,17 +10,20 @@ import (
 	"strings"
 )
 
-func EmitSlot(root string) error {
+func EmitSlot(root string, option ...Option) error {
 	// 取项目模块名
 	module, err := getProjectModuleName(root)
 	if err != nil {
 		return err
 	}
 	e := &slotEmiter{
-		root:     root,
-		module:   module,
-		exportTo: fmt.Sprintf("%s/internal/srpc/slot", module),
-		outDir:   path.Join(root, "internal", "srpc", "slot"),
+		root:      root,
+		module:    module,
+		exportTo:  fmt.Sprintf("%s/internal/srpc/slot", module),
+		outDir:    path.Join(root, "internal", "srpc", "slot"),
+		option:    firstOption(option),
+		providers: map[*parse.StructType]string{},
+		objects:   map[string]*parse.StructType{},
 	}
 	err = e.emit()
 	if err != nil {
@@ -33,11 +37,17 @@ type slotEmiter struct {
 	module        string
 	outDir        string
 	exportTo      string
+	option        Option
 	targetStructs []*parse.StructType
+	// internal/logic 之外声明的结构体 => 包路径, 实例通过 manager.Provide 注册
+	providers map[*parse.StructType]string
+	// 远程对象名称 => 结构体, 用于检查重复的名称
+	objects map[string]*parse.StructType
+	smap    *util.SourceMap
 }
 
 func (e *slotEmiter) emit() error {
-	dirs, err := listDir(path.Join(e.root, "internal", "logic"))
+	dirs, err := e.slotDirs()
 	if err != nil {
 		return err
 	}
@@ -52,8 +62,16 @@ func (e *slotEmiter) emit() error {
 	if err != nil {
 		return err
 	}
+	logicDir := path.Join(e.root, "internal", "logic")
 	for _, dir := range dirs {
-		err := e.emitSlotDir(dir)
+		err := e.emitSlotDir(dir, !strings.HasPrefix(dir, logicDir+"/"))
+		if err != nil {
+			return err
+		}
+	}
+	// 生成契约模块
+	if len(e.option.Contract) > 0 {
+		err = emitContract(e.root, e.module, e.targetStructs, e.option)
 		if err != nil {
 			return err
 		}
@@ -66,9 +84,24 @@ func (e *slotEmiter) emit() error {
 	if err != nil {
 		return err
 	}
-	if has {
+	timeout := defaultTimeout(e.option)
+	if has || timeout > 0 {
+		writer.WriteEmptyLine()
+		writer.WriteString("import (").WriteLine().IncreaseIndent()
+		if timeout > 0 {
+			writer.WriteString("\"", e.module, "/internal/srpc/manager\"").WriteLine()
+		}
+		if has {
+			writer.WriteString("_ \"", e.module, "/internal/srpc/slot\"").WriteLine()
+		}
+		writer.DecreaseIndent().WriteString(")").WriteLine()
+	}
+	// 默认的执行时间上限只注册一次
+	if timeout > 0 {
 		writer.WriteEmptyLine()
-		writer.WriteString("import _ \"", e.module, "/internal/srpc/slot\"").WriteLine()
+		writer.WriteString("func init() {").WriteLine().IncreaseIndent()
+		emitTimeout(writer, "", timeout)
+		writer.DecreaseIndent().WriteString("}").WriteLine()
 	}
 	err = util.WriteGenerateFile(path.Join(e.root, "internal", "srpc", "slot.go"), writer.Bytes(), e.root)
 	if err != nil {
@@ -77,8 +110,46 @@ func (e *slotEmiter) emit() error {
 	return nil
 }
 
-func (e *slotEmiter) emitSlotDir(dir string) error {
-	files, err := listFile(dir)
+// slotDirs 扫描 slot 结构体的目录: internal/logic 下的所有子目录和 Option.SlotDirs 中的目录
+func (e *slotEmiter) slotDirs() ([]string, error) {
+	dirs, err := listDir(path.Join(e.root, "internal", "logic"), true)
+	if err != nil {
+		return nil, err
+	}
+	seen := map[string]bool{}
+	for _, dir := range dirs {
+		seen[dir] = true
+	}
+	for _, pattern := range e.option.SlotDirs {
+		dir := path.Join(e.root, strings.TrimSuffix(pattern, "/..."))
+		ok, err := fileExist(dir)
+		if err != nil {
+			return nil, err
+		}
+		if !ok {
+			return nil, errors.New("slot directory " + pattern + " not found")
+		}
+		list := []string{dir}
+		if strings.HasSuffix(pattern, "/...") {
+			sub, err := listDir(dir, true)
+			if err != nil {
+				return nil, err
+			}
+			list = append(list, sub...)
+		}
+		for _, v := range list {
+			if !seen[v] {
+				seen[v] = true
+				dirs = append(dirs, v)
+			}
+		}
+	}
+	return dirs, nil
+}
+
+// emitSlotDir 生成目录中的 slot 结构体, provided 为 true 时目录在 internal/logic 之外
+func (e *slotEmiter) emitSlotDir(dir string, provided bool) error {
+	files, err := parse.ListGoFiles(dir, e.option.Tags...)
 	if err != nil {
 		return err
 	}
@@ -93,6 +164,9 @@ func (e *slotEmiter) emitSlotDir(dir string) error {
 	}
 	// 合并结构类型
 	structs := parse.CombineStructTypes(astFiles)
+	checker := newWireChecker(e.root, e.module, e.option)
+	// 当前目录中的 slot 结构体
+	var targets []*parse.StructType
 	// 提取出 slot 和 listen
 	for _, st := range structs {
 		// 去掉无类型名称的结构体
@@ -100,50 +174,77 @@ func (e *slotEmiter) emitSlotDir(dir string) error {
 			continue
 		}
 		if isSlotStruct(st) {
+			err = checkDirectives(st.Functions, e.root)
+			if err != nil {
+				return err
+			}
 			st.Functions = e.filterNoExport(st.Functions)
 			if len(st.Functions) == 0 {
 				continue
 			}
-			e.targetStructs = append(e.targetStructs, st)
+			err = e.checkObjectName(st)
+			if err != nil {
+				return err
+			}
+			if provided {
+				pkgPath, err := util.GetGoFilePackagePath(e.root, e.module, st.Parent.FileName)
+				if err != nil {
+					return err
+				}
+				e.providers[st] = pkgPath
+			}
+			// 检查参数和返回值能否通过 json 传输
+			for _, f := range st.Functions {
+				err = checker.checkFunction(slotObjectName(st), f)
+				if err != nil {
+					return err
+				}
+			}
+			targets = append(targets, st)
 			continue
 		}
 	}
-	// 无内容则不生成
-	if len(e.targetStructs) == 0 {
-		return nil
-	}
+	e.targetStructs = append(e.targetStructs, targets...)
 	// 开始生成代码, 一个结构体对应一个文件
-	for _, st := range e.targetStructs {
+	for _, st := range targets {
 		writer := util.NewTextWriter()
 		writer.WriteString(generatedHeader).WriteLine()
 		writer.WriteString("package slot").WriteLine()
 		// 处理 import
-		collect := newImportCollect()
+		collect := newImportCollect(e.root)
 		// collect.Set("srpc", "github.com/aundis/srpc")
-		collect.Set("service", e.module+"/internal/service")
-		collect.Set("manager", e.module+"/internal/srpc/manager")
-		if isSlotStruct(st) {
-			collect.Set("meta", "github.com/aundis/meta")
+		if _, ok := e.providers[st]; !ok {
+			collect.Set("service", e.module+"/internal/service")
 		}
+		collect.Set("manager", e.module+"/internal/srpc/manager")
 		if structNeedImportJson(st) {
 			collect.Set("json", "encoding/json")
 		}
-		err = resolveStructImports(st, collect, e.exportTo, e.module, e.root)
+		err = resolveStructImports(st, collect, e.exportTo, e.module, e.root, e.option)
 		if err != nil {
 			return err
 		}
 		writer.WriteEmptyLine()
 		collect.Emit(writer)
+		// 确保结构体所在的包被导入, 在 init 中注册实例
+		if pkgPath, ok := e.providers[st]; ok && len(collect.Name(pkgPath)) == 0 {
+			writer.WriteString(`import _ "`, pkgPath, `"`).WriteLine()
+		}
 		// emit
-		err = e.emitStruct(writer, st)
+		e.smap = util.NewSourceMap()
+		err = e.emitStruct(writer, st, collect)
 		if err != nil {
 			return err
 		}
-		filename := path.Join(e.outDir, toSnakeCase(st.Name[1:])+".go")
+		filename := path.Join(e.outDir, toSnakeCase(slotObjectName(st))+".go")
 		err = util.WriteGenerateFile(filename, writer.Bytes(), e.root)
 		if err != nil {
 			return err
 		}
+		err = util.WriteSourceMap(filename, e.smap)
+		if err != nil {
+			return err
+		}
 	}
 	return nil
 }
@@ -166,6 +267,10 @@ func (e *slotEmiter) filterNoExport(list []*parse.Function) []*parse.Function {
 		if !(v.Name[0] >= 'A' && v.Name[0] <= 'Z') {
 			continue
 		}
+		// 使用 //sr:ignore 忽略的方法
+		if isIgnored(v) {
+			continue
+		}
 		// 首个参数必须为 context.Context
 		if len(v.Params) == 0 || v.Params[0].Type != "context.Context" {
 			fmt.Println("warning: " + formatError(v.Parent.FileSet, v.Pos, "first paramater type not context.Context, ignore method "+v.Name, e.root).Error())
@@ -181,8 +286,8 @@ func (e *slotEmiter) filterNoExport(list []*parse.Function) []*parse.Function {
 	return result
 }
 
-func (e *slotEmiter) emitStruct(writer util.TextWriter, st *parse.StructType) error {
-	fResolver := newFieldResolver(e.root, e.module, e.exportTo)
+func (e *slotEmiter) emitStruct(writer util.TextWriter, st *parse.StructType, collect *importCollect) error {
+	fResolver := newFieldResolver(e.root, e.module, e.exportTo, collect, e.option)
 	for _, v := range getStructFields(st) {
 		err := fResolver.resolve(v)
 		if err != nil {
@@ -197,61 +302,119 @@ func (e *slotEmiter) emitStruct(writer util.TextWriter, st *parse.StructType) er
 		// 	P2 int `json:"B"`
 		// }
 		if len(f.Params) > 1 {
-			reqStructName := firstLower(st.Name[1:]) + f.Name + "Request"
+			reqStructName := firstLower(slotObjectName(st)) + f.Name + "Request"
 			writer.WriteEmptyLine()
+			start := writer.Line()
 			writer.WriteString("type ", reqStructName, " struct {").WriteLine().IncreaseIndent()
 			for i, p := range f.Params {
 				if i == 0 {
 					continue
 				}
-				fieldName := "p" + strconv.Itoa(i)
-				// Name string `json:"name"`
-				writer.WriteString(firstUpper(fieldName), " ", strings.ReplaceAll(fResolver.getResolvedType(p), "...", "[]"), " `json:\"", fieldName, "\"`").WriteLine()
+				fieldName := "P" + strconv.Itoa(i)
+				// P1 string `json:"name" v:"required"`
+				tag := "json:" + strconv.Quote(wireKey(e.option, p, "p", i))
+				if rule := validRule(f, p.Name); len(rule) > 0 {
+					tag += " v:" + strconv.Quote(rule)
+				}
+				writer.WriteString(fieldName, " ", strings.ReplaceAll(fResolver.getResolvedType(p), "...", "[]"), " `", tag, "`").WriteLine()
 			}
 			writer.DecreaseIndent().WriteString("}").WriteLine()
+			addSourceMapping(e.smap, writer, start, f.Parent.FileSet, f.Pos, f.End, st.Name+"."+f.Name, e.root)
 		}
 	}
 
 	writer.WriteEmptyLine()
 	writer.WriteString("func init() {").WriteLine().IncreaseIndent()
+	// 声明的允许的调用方
+	emitAllowCallers(writer, slotObjectName(st), slotAllowedCallers(st))
+	for _, f := range st.Functions {
+		emitAllowCallers(writer, slotObjectName(st)+"."+actionName(f), allowedCallers(f))
+	}
+	// 声明的并发数量和速率限制
+	for _, f := range st.Functions {
+		emitLimit(writer, slotObjectName(st)+"."+actionName(f), methodLimit(f))
+	}
+	// 声明的执行时间上限, 默认值在 internal/srpc/slot.go 中注册
+	for _, f := range st.Functions {
+		emitTimeout(writer, slotObjectName(st)+"."+actionName(f), methodTimeout(f))
+	}
 	// 这里面放请求方法
 	for _, f := range st.Functions {
-		action := st.Name[1:] + "." + f.Name
+		action := slotObjectName(st) + "." + actionName(f)
+		start := writer.Line()
 		writer.WriteString(`manager.AddController("`, action, `", func(ctx context.Context, req []byte) (res interface{}, err error) {`).WriteLine().IncreaseIndent()
+		// 恢复逻辑方法中的 panic, 避免中断服务的连接
+		writer.WriteString(`defer manager.Recover(ctx, "`, action, `", &err)`).WriteLine()
+		// 检查调用方是否有权限调用
+		writer.WriteString(`err = manager.CheckCaller(ctx, "`, action, `")`).WriteLine()
+		writer.WriteString("if err != nil {").WriteLine().IncreaseIndent()
+		writer.WriteString("return").WriteLine()
+		writer.DecreaseIndent().WriteString("}").WriteLine()
 
 		// 	var params *ParamStruct
 		// 	err = json.Unmarshal(req, &params)
 		// 	if err != nil {
 		// 		return
 		// 	}
+		// 	err = manager.Validate(ctx, params)
+		// 	if err != nil {
+		// 		return
+		// 	}
 		if len(f.Params) > 1 {
-			reqStructName := firstLower(st.Name[1:]) + f.Name + "Request"
+			reqStructName := firstLower(slotObjectName(st)) + f.Name + "Request"
+			// 兼容旧版本调用方发送的位置键
+			if keys := renameKeysCode(e.option, f.Params[1:], "p"); len(keys) > 0 {
+				writer.WriteString("req, err = manager.RenameKeys(req, ", keys, ")").WriteLine()
+				writer.WriteString("if err != nil {").WriteLine().IncreaseIndent()
+				writer.WriteString("return").WriteLine()
+				writer.DecreaseIndent().WriteString("}").WriteLine()
+			}
 			writer.WriteString("var params *" + reqStructName).WriteLine()
 			writer.WriteString("err = json.Unmarshal(req, &params)").WriteLine()
 			writer.WriteString("if err != nil {").WriteLine().IncreaseIndent()
 			writer.WriteString("return").WriteLine()
 			writer.DecreaseIndent().WriteString("}").WriteLine()
+			// 按照参数和参数类型上的规则校验
+			writer.WriteString("err = manager.Validate(ctx, params)").WriteLine()
+			writer.WriteString("if err != nil {").WriteLine().IncreaseIndent()
+			writer.WriteString("return").WriteLine()
+			writer.DecreaseIndent().WriteString("}").WriteLine()
 		}
 
-		// 	r1, r2, r3, err := target(ctx, params.P1, params.P2)
+		// 	var r1 int
+		// 	release, err := manager.Acquire(ctx, "Order.Create")
 		// 	if err != nil {
 		// 		return
 		// 	}
-		for i := range f.Results {
+		// 	err = manager.Run(ctx, "Order.Create", release, func(ctx context.Context) (err error) {
+		// 		r1, err = target(ctx, params.P1, params.P2)
+		// 		return
+		// 	})
+		// 	if err != nil {
+		// 		return
+		// 	}
+		for i, r := range f.Results {
 			if i == len(f.Results)-1 {
 				continue
 			}
-			fieldName := "r" + strconv.Itoa(i+1)
-			writer.WriteString(fieldName, ", ")
+			writer.WriteString("var r", strconv.Itoa(i+1), " ", fResolver.getResolvedType(r)).WriteLine()
 		}
-		writer.WriteString("err")
-		if len(f.Results) == 1 {
-			writer.WriteString(" = ")
-		} else {
-			writer.WriteString(" := ")
+		// 超过并发数量或速率限制时返回过载错误
+		writer.WriteString(`release, err := manager.Acquire(ctx, "`, action, `")`).WriteLine()
+		writer.WriteString("if err != nil {").WriteLine().IncreaseIndent()
+		writer.WriteString("return").WriteLine()
+		writer.DecreaseIndent().WriteString("}").WriteLine()
+		// 在新的 goroutine 中调用, 超过执行时间上限时不再等待, 服务返回后才释放并发数量
+		writer.WriteString(`err = manager.Run(ctx, "`, action, `", release, func(ctx context.Context) (err error) {`).WriteLine().IncreaseIndent()
+		for i := range f.Results {
+			if i == len(f.Results)-1 {
+				continue
+			}
+			writer.WriteString("r", strconv.Itoa(i+1), ", ")
 		}
+		writer.WriteString("err = ")
 		// service.XXX().(ctx
-		writer.WriteString("service.", st.Name[1:], "().", f.Name, "(ctx")
+		writer.WriteString(e.instanceCode(st), ".", f.Name, "(ctx")
 		paramIndex := 1
 		if len(f.Params) > 1 {
 			for i, v := range f.Params {
@@ -269,12 +432,14 @@ func (e *slotEmiter) emitStruct(writer util.TextWriter, st *parse.StructType) er
 			}
 		}
 		writer.WriteString(")").WriteLine()
+		writer.WriteString("return").WriteLine()
+		writer.DecreaseIndent().WriteString("})").WriteLine()
 		writer.WriteString("if err != nil {").WriteLine().IncreaseIndent()
 		writer.WriteString("return").WriteLine()
 		writer.DecreaseIndent().WriteString("}").WriteLine()
 
 		// 	res = map[string]interface{} {
-		// 		"r1": r1,
+		// 		"id": r1,
 		// 		"r2": r2,
 		// 		"r3": r3,
 		// 	}
@@ -285,29 +450,88 @@ func (e *slotEmiter) emitStruct(writer util.TextWriter, st *parse.StructType) er
 					continue
 				}
 				fieldName := "r" + strconv.Itoa(i+1)
-				writer.WriteString(`"`, fieldName, `": `, fieldName, ",").WriteLine()
+				for _, key := range sendKeys(e.option, v, "r", i+1) {
+					writer.WriteString(`"`, key, `": `, fieldName, ",").WriteLine()
+				}
 			}
 		}
 		writer.DecreaseIndent().WriteString("}").WriteLine()
 		writer.WriteString("return").WriteLine()
 		writer.DecreaseIndent().WriteString("})").WriteLine()
+		addSourceMapping(e.smap, writer, start, f.Parent.FileSet, f.Pos, f.End, st.Name+"."+f.Name, e.root)
 	}
 
 	if isSlotStruct(st) {
 		// writer.WriteEmptyLine()
+		start := writer.Line()
 		writer.WriteString("// Object Helper").WriteLine()
-		err := emitSlotHelper(e.root, e.module, writer, st)
+		err := emitSlotHelper(e.root, e.module, writer, st, e.option)
 		if err != nil {
 			return err
 		}
+		addSourceMapping(e.smap, writer, start, st.Parent.FileSet, st.Pos, st.End, st.Name, e.root)
 	}
 	writer.DecreaseIndent().WriteString("}").WriteLine()
+	if _, ok := e.providers[st]; ok {
+		e.emitProvided(writer, st, fResolver)
+	}
+	return nil
+}
+
+// checkObjectName 检查 meta.Slot 标签, 不同的结构体不能对应同一个远程对象
+func (e *slotEmiter) checkObjectName(st *parse.StructType) error {
+	err := checkSlotTag(st, e.root)
+	if err != nil {
+		return err
+	}
+	name := slotObjectName(st)
+	if other, ok := e.objects[name]; ok {
+		p := other.Parent.FileSet.Position(other.Pos)
+		at := fmt.Sprintf("%s:%d", util.TryConvRelPath(e.root, p.Filename), p.Line)
+		return formatError(st.Parent.FileSet, st.Pos, "object name "+name+" of struct "+st.Name+" already used by struct "+other.Name+" at "+at, e.root)
+	}
+	e.objects[name] = st
 	return nil
 }
 
+// instanceCode 调用方法的实例, internal/logic 中的结构体通过 service 获取
+func (e *slotEmiter) instanceCode(st *parse.StructType) string {
+	if _, ok := e.providers[st]; ok {
+		return "provided" + slotObjectName(st) + "()"
+	}
+	return "service." + serviceName(st) + "()"
+}
+
+// emitProvided 生成获取通过 manager.Provide 注册的实例的函数, 结构体可以不导出, 通过接口调用,
+// 没有注册时 panic, 由控制器中的 manager.Recover 处理
+func (e *slotEmiter) emitProvided(writer util.TextWriter, st *parse.StructType, fResolver *fieldResolver) {
+	name := slotObjectName(st)
+	writer.WriteEmptyLine()
+	writer.WriteString("type i", name, " interface {").WriteLine().IncreaseIndent()
+	for _, f := range st.Functions {
+		var params, results []string
+		for _, p := range f.Params {
+			params = append(params, fResolver.getResolvedType(p))
+		}
+		for _, r := range f.Results {
+			results = append(results, fResolver.getResolvedType(r))
+		}
+		writer.WriteString(f.Name, "(", strings.Join(params, ", "), ") (", strings.Join(results, ", "), ")").WriteLine()
+	}
+	writer.DecreaseIndent().WriteString("}").WriteLine()
+	writer.WriteEmptyLine()
+	writer.WriteString("func provided", name, "() i", name, " {").WriteLine().IncreaseIndent()
+	writer.WriteString(`impl, ok := manager.Provided("`, name, `").(i`, name, ")").WriteLine()
+	writer.WriteString("if !ok {").WriteLine().IncreaseIndent()
+	writer.WriteString(`panic("srpc: `, name, ` is not provided, register it with manager.Provide(\"`, name, `\", impl)")`).WriteLine()
+	writer.DecreaseIndent().WriteString("}").WriteLine()
+	writer.WriteString("return impl").WriteLine()
+	writer.DecreaseIndent().WriteString("}").WriteLine()
+}
+
 func isSlotStruct(tpe *parse.StructType) bool {
 	for _, v := range tpe.Fields {
-		if v.Type == "meta.Slot" {
+		if v.Embedded && v.Type == "meta.Slot" {
 			return true
 		}
 	}
@@ -1,6 +1,12 @@
 package emit
 
-import "testing"
+import (
+	"os"
+	"path"
+	"sr/parse"
+	"strings"
+	"testing"
+)
 
 func TestSlot(t *testing.T) {
 	err := EmitSlot(`C:\Users\85124\Desktop\abc`)
@@ -9,3 +15,94 @@ func TestSlot(t *testing.T) {
 		return
 	}
 }
+
+func TestSlotDirs(t *testing.T) {
+	root := t.TempDir()
+	for _, dir := range []string{"internal/logic/order/refund", "internal/logic/order/testdata", "internal/logic/.cache", "internal/payment/card"} {
+		err := os.MkdirAll(path.Join(root, dir), 0755)
+		if err != nil {
+			t.Error(err)
+			return
+		}
+	}
+	e := &slotEmiter{root: root, option: Option{SlotDirs: []string{"internal/payment/...", "internal/logic/order"}}}
+	dirs, err := e.slotDirs()
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	var list []string
+	for _, dir := range dirs {
+		list = append(list, strings.TrimPrefix(dir, root+"/"))
+	}
+	except := "internal/logic/order,internal/logic/order/refund,internal/payment,internal/payment/card"
+	if strings.Join(list, ",") != except {
+		t.Errorf("except dirs %s but got %s", except, strings.Join(list, ","))
+		return
+	}
+	e.option.SlotDirs = []string{"internal/billing"}
+	if _, err := e.slotDirs(); err == nil {
+		t.Errorf("except error but got nil")
+	}
+}
+
+func TestSlotObjectName(t *testing.T) {
+	cases := []struct {
+		code   string
+		except string
+		err    string
+	}{
+		{"type sOrder struct {\n\tmeta.Slot\n}", "Order", ""},
+		{"type Order struct {\n\tmeta.Slot\n}", "Order", ""},
+		{"type status struct {\n\tmeta.Slot\n}", "status", ""},
+		{"type sOrderV2 struct {\n\tmeta.Slot `name:\"Order\" version:\"v2\"`\n}", "Order", ""},
+		{"type sOrder struct {\n\tmeta.Slot `name:\"order\"`\n}", "", "name in meta.Slot tag requires an exported identifier"},
+		{"type sOrder struct {\n\tmeta.Slot `name:\"Order.V2\"`\n}", "", "name in meta.Slot tag requires an exported identifier"},
+		// rename 不是 name
+		{"type sOrder struct {\n\tmeta.Slot `rename:\"Other\"`\n}", "Order", ""},
+		{"type sOrder struct {\n\tmeta.Slot `name:\"\"`\n}", "", "name in meta.Slot tag requires an exported identifier"},
+	}
+	for _, c := range cases {
+		f, err := parse.ParseContent("test.go", []byte("package p\n\n"+c.code))
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		st := f.StructTypes[0]
+		err = checkSlotTag(st, "")
+		if len(c.err) > 0 {
+			if err == nil || !strings.Contains(err.Error(), c.err) {
+				t.Errorf("except error %s but got %v", c.err, err)
+				return
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("except no error but got %v", err)
+			return
+		}
+		if name := slotObjectName(st); name != c.except {
+			t.Errorf("except object name %s but got %s", c.except, name)
+			return
+		}
+	}
+}
+
+func TestCheckObjectName(t *testing.T) {
+	f, err := parse.ParseContent("test.go", []byte("package p\n\ntype sOrder struct {\n\tmeta.Slot\n}\n\ntype sOrderV2 struct {\n\tmeta.Slot `name:\"Order\"`\n}"))
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	e := &slotEmiter{objects: map[string]*parse.StructType{}}
+	err = e.checkObjectName(f.StructTypes[0])
+	if err != nil {
+		t.Errorf("except no error but got %v", err)
+		return
+	}
+	err = e.checkObjectName(f.StructTypes[1])
+	except := "object name Order of struct sOrderV2 already used by struct sOrder at test.go:3"
+	if err == nil || !strings.Contains(err.Error(), except) {
+		t.Errorf("except error %s but got %v", except, err)
+	}
+}
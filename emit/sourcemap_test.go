@@ -0,0 +1,129 @@
+package emit
+
+import (
+	"os"
+	"path"
+	"sr/util"
+	"strings"
+	"testing"
+)
+
+func TestSlotSourceMap(t *testing.T) {
+	root := t.TempDir()
+	files := map[string]string{
+		"go.mod": "module abc\n",
+		"internal/logic/order/order.go": `package order
+
+import (
+	"context"
+
+	"github.com/aundis/meta"
+)
+
+type sOrder struct {
+	meta.Slot
+}
+
+// Create 创建订单
+func (s *sOrder) Create(ctx context.Context, user string, count int) (int, error) {
+	return 0, nil
+}
+
+// List 订单列表
+func (s *sOrder) List(ctx context.Context) ([]int, error) {
+	return nil, nil
+}
+`,
+	}
+	for name, content := range files {
+		filename := path.Join(root, name)
+		err := os.MkdirAll(path.Dir(filename), 0755)
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		err = os.WriteFile(filename, []byte(content), 0644)
+		if err != nil {
+			t.Error(err)
+			return
+		}
+	}
+	err := EmitSlot(root)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	filename := path.Join(root, "internal", "srpc", "slot", "order.go")
+	content, err := os.ReadFile(filename)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	smap, err := util.ReadSourceMap(filename)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	lines := strings.Split(string(content), "\n")
+	// 与 sr explain 相同, 按照生成文件中的真实行号查找
+	lineOf := func(text string) int {
+		for i, line := range lines {
+			if strings.Contains(line, text) {
+				return i + 1
+			}
+		}
+		t.Fatalf("except line %s in generated file", text)
+		return 0
+	}
+	cases := []struct {
+		text   string
+		offset int
+		except string
+	}{
+		{"type orderCreateRequest struct {", 0, "sOrder.Create"},
+		{"type orderCreateRequest struct {", 3, "sOrder.Create"},
+		{`manager.AddController("Order.Create"`, 0, "sOrder.Create"},
+		{`manager.AddController("Order.Create"`, 5, "sOrder.Create"},
+		{`manager.AddController("Order.List"`, 0, "sOrder.List"},
+		{"// Object Helper", 0, "sOrder"},
+	}
+	// 请求结构体的结束行之后是空行, 不来源于任何声明
+	if mapping := smap.Find(lineOf("type orderCreateRequest struct {") + 4); mapping != nil {
+		t.Errorf("except blank line not mapped but got %+v", mapping)
+	}
+	for _, c := range cases {
+		line := lineOf(c.text)
+		mapping := smap.Find(line + c.offset)
+		if mapping == nil || mapping.Name != c.except {
+			t.Errorf("except %s+%d mapped to %s but got %+v", c.text, c.offset, c.except, mapping)
+			return
+		}
+		// 映射从声明生成的第一行开始
+		if c.offset == 0 && mapping.StartLine != line {
+			t.Errorf("except %s mapping start at line %d but got %d", c.except, line, mapping.StartLine)
+			return
+		}
+		if line := mappingSourceLine(files["internal/logic/order/order.go"], c.except); mapping.Line != line {
+			t.Errorf("except %s declared at line %d but got %d", c.except, line, mapping.Line)
+			return
+		}
+	}
+	// 生成文件的头部和 import 不来源于任何声明
+	if mapping := smap.Find(1); mapping != nil {
+		t.Errorf("except header not mapped but got %+v", mapping)
+	}
+}
+
+// mappingSourceLine 声明在源码中的行号
+func mappingSourceLine(content, name string) int {
+	decl := "type sOrder struct"
+	if index := strings.Index(name, "."); index >= 0 {
+		decl = "func (s *sOrder) " + name[index+1:] + "("
+	}
+	for i, line := range strings.Split(content, "\n") {
+		if strings.HasPrefix(line, decl) {
+			return i + 1
+		}
+	}
+	return 0
+}
@@ -0,0 +1,13 @@
+package api
+
+import . "abc/model"
+
+type Account = User
+
+type Count = int
+
+type Order struct {
+	Account Account
+	User    User
+	Count   Count
+}
@@ -0,0 +1,8 @@
+package rename
+
+//sr:from abc/billing.Status
+type Status int
+
+const (
+	Pending Status = iota
+)
@@ -0,0 +1,8 @@
+package rename
+
+//sr:from abc/user.Profile
+type Profile struct {
+	Name string
+}
+
+type Legacy struct{}
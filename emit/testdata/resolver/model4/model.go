@@ -0,0 +1,27 @@
+package model4
+
+import (
+	"abc/model2"
+	"context"
+)
+
+type item struct {
+	Name string
+}
+
+type wrapper struct {
+	*model2.M2
+	First, Last item
+	Extra       struct {
+		Items []item
+	}
+}
+
+type M4 struct {
+	Items   []*model2.M2
+	Index   map[string]model2.M2
+	Handler func(ctx context.Context) error
+	M2      model2.M2
+	Item    item
+	wrapper
+}
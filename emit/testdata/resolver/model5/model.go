@@ -0,0 +1,29 @@
+package model5
+
+import "fmt"
+
+// Status 订单状态
+type Status int
+
+const (
+	StatusPending Status = iota + 1
+	StatusPaid
+)
+
+// String 状态名称
+func (s Status) String() string {
+	if s == StatusPending {
+		return "pending"
+	}
+	return fmt.Sprintf("status(%d)", int(s))
+}
+
+func (s Status) Label() string {
+	return prefix + s.String()
+}
+
+var prefix = "status: "
+
+type M5 struct {
+	Status Status
+}
@@ -0,0 +1,16 @@
+package model6
+
+import "abc/model7"
+
+// Node 树节点
+type Node struct {
+	Name     string
+	Children []*Node
+	Parent   *Node
+}
+
+type Order struct {
+	Id       int
+	Customer *model7.Customer
+	Tree     map[string]Node
+}
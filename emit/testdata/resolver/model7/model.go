@@ -0,0 +1,8 @@
+package model7
+
+import "abc/model6"
+
+type Customer struct {
+	Name   string
+	Orders []model6.Order
+}
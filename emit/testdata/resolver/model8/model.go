@@ -0,0 +1,71 @@
+package model8
+
+import (
+	"encoding/json"
+	"errors"
+	"strings"
+
+	"abc/model6"
+	"github.com/foo/bar"
+)
+
+type Holder struct {
+	Account Account
+	Names   List[string]
+}
+
+type List[T any] struct {
+	Items []T
+}
+
+func (l *List[T]) Len() int {
+	return len(l.Items)
+}
+
+var defaultName = "guest"
+
+// Account 账户
+type Account struct {
+	Name  string
+	Email string
+	Node  *model6.Node
+}
+
+// Validate 校验账户
+func (a *Account) Validate() error {
+	if a.Name == "" {
+		return errors.New("name is required")
+	}
+	return a.checkEmail()
+}
+
+func (a *Account) checkEmail() error {
+	if !strings.Contains(a.Email, "@") {
+		return errors.New("invalid email")
+	}
+	return nil
+}
+
+// MarshalJSON 使用默认的编码
+func (a Account) MarshalJSON() ([]byte, error) {
+	type alias Account
+	return json.Marshal(alias(a))
+}
+
+// Normalize 引用了包级别的变量
+func (a *Account) Normalize() {
+	if a.Name == "" {
+		a.Name = defaultName
+	}
+}
+
+// Display 调用了无法复制的方法
+func (a *Account) Display() string {
+	a.Normalize()
+	return a.Name
+}
+
+// Tag 引用了第三方包
+func (a *Account) Tag() string {
+	return bar.Tag(a.Name)
+}
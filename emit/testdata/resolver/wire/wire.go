@@ -0,0 +1,71 @@
+package wire
+
+import (
+	"context"
+	"time"
+)
+
+type Point struct {
+	X int `json:"x"`
+	Y int
+}
+
+type Key struct {
+	Name string
+}
+
+type Code string
+
+type Handler struct {
+	Callback func()
+}
+
+type Node struct {
+	Next *Node `json:"next"`
+}
+
+type secret struct {
+	value string
+}
+
+type Mixed struct {
+	a, B chan int
+}
+
+type Stamp struct {
+	Fn func()
+}
+
+func (s Stamp) MarshalJSON() ([]byte, error) {
+	return nil, nil
+}
+
+type sWire struct{}
+
+func (s *sWire) Good(ctx context.Context, p Point, m map[Code][]*Point, t time.Time, st Stamp) (map[int]Point, error) {
+	return nil, nil
+}
+
+func (s *sWire) Chan(ctx context.Context, c chan int) error {
+	return nil
+}
+
+func (s *sWire) Func(ctx context.Context, h *Handler) error {
+	return nil
+}
+
+func (s *sWire) StructKey(ctx context.Context, m map[Key]int) error {
+	return nil
+}
+
+func (s *sWire) Mixed(ctx context.Context, m Mixed) error {
+	return nil
+}
+
+func (s *sWire) Complex(ctx context.Context) ([]complex128, error) {
+	return nil, nil
+}
+
+func (s *sWire) Warn(ctx context.Context, v interface{}, n *Node, x secret) error {
+	return nil
+}
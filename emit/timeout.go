@@ -0,0 +1,54 @@
+package emit
+
+import (
+	"errors"
+	"sr/parse"
+	"sr/util"
+	"time"
+)
+
+// 方法的执行时间上限, 方法上使用 //sr:timeout 指令声明, e.g. //sr:timeout 5s,
+// 没有声明时使用 Option.Timeout, 运行时配置 srpc.timeout 中的同名设置优先, Helper.list 中公开运行时生效的值
+
+func parseTimeout(s string) (time.Duration, error) {
+	d, err := time.ParseDuration(s)
+	if err != nil || d <= 0 {
+		return 0, errors.New("timeout requires a positive duration, e.g. 5s, but got \"" + s + "\"")
+	}
+	return d, nil
+}
+
+func checkTimeoutOption(option Option) error {
+	if len(option.Timeout) == 0 {
+		return nil
+	}
+	_, err := parseTimeout(option.Timeout)
+	return err
+}
+
+// methodTimeout 方法上声明的超时时间, 没有时为 0, 指令已经在 checkDirectives 中校验过
+func methodTimeout(fun *parse.Function) time.Duration {
+	d := parse.FindDirective(fun.Directives, directiveTimeout)
+	if d == nil {
+		return 0
+	}
+	timeout, _ := parseTimeout(d.Args)
+	return timeout
+}
+
+// defaultTimeout 选项中的默认超时时间, 没有时为 0
+func defaultTimeout(option Option) time.Duration {
+	if len(option.Timeout) == 0 {
+		return 0
+	}
+	timeout, _ := parseTimeout(option.Timeout)
+	return timeout
+}
+
+// emitTimeout 注册声明的超时时间, action 为空时为默认超时时间, e.g. manager.SetTimeout("Order.Search", "5s")
+func emitTimeout(writer util.TextWriter, action string, timeout time.Duration) {
+	if timeout <= 0 {
+		return
+	}
+	writer.WriteString(`manager.SetTimeout("`, action, `", "`, timeout.String(), `")`).WriteLine()
+}
@@ -0,0 +1,52 @@
+package emit
+
+import (
+	"sr/parse"
+	"sr/util"
+	"strings"
+	"testing"
+)
+
+func TestMethodTimeout(t *testing.T) {
+	cases := []struct {
+		code   string
+		except string
+	}{
+		{"//sr:timeout 5s\nfunc (s *sUser) Get() {}", "5s"},
+		{"//sr:timeout 90s\nfunc (s *sUser) Get() {}", "1m30s"},
+		{"func (s *sUser) Get() {}", "0s"},
+	}
+	for _, c := range cases {
+		f, err := parse.ParseContent("test.go", []byte("package p\n\n"+c.code))
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		if timeout := methodTimeout(f.Functions[0]); timeout.String() != c.except {
+			t.Errorf("except timeout %s but got %s", c.except, timeout)
+			return
+		}
+	}
+	if timeout := defaultTimeout(Option{Timeout: "30s"}); timeout.String() != "30s" {
+		t.Errorf("except timeout 30s but got %s", timeout)
+	}
+}
+
+func TestEmitTimeout(t *testing.T) {
+	writer := util.NewTextWriter()
+	emitTimeout(writer, "", 0)
+	d, err := parseTimeout("1500ms")
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	emitTimeout(writer, "Order.Search", d)
+	except := `manager.SetTimeout("Order.Search", "1.5s")`
+	if code := strings.TrimSpace(string(writer.Bytes())); code != except {
+		t.Errorf("except code %s but got %s", except, code)
+		return
+	}
+	if err := CheckOption(Option{Timeout: "-1s"}); err == nil {
+		t.Errorf("except error but got nil")
+	}
+}
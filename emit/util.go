@@ -11,11 +11,10 @@ import (
 	"path"
 	"regexp"
 	"runtime"
-	"sr/parse"
 	"sr/util"
+	"strconv"
 	"strings"
 
-	"github.com/aundis/meta"
 	"github.com/gogf/gf/v2/os/gfile"
 )
 
@@ -48,6 +47,19 @@ func formatError(fset *token.FileSet, pos token.Pos, message string, root ...str
 	return errors.New(fmt.Sprintf("%s:%d:%d: %s", filename, p.Line, p.Column, message))
 }
 
+// addSourceMapping 记录 writer 中从 start 行到上一行的代码来源于 pos 处的声明,
+// start 在写入块的第一行之前通过 writer.Line() 取得, 块以 WriteLine 结束
+func addSourceMapping(smap *util.SourceMap, writer util.TextWriter, start int, fset *token.FileSet, pos, end token.Pos, name string, root string) {
+	if smap == nil || !pos.IsValid() {
+		return
+	}
+	endPos := fset.Position(pos)
+	if end.IsValid() {
+		endPos = fset.Position(end)
+	}
+	smap.Add(start, writer.Line()-1, name, fset.Position(pos), endPos, root)
+}
+
 func listFile(dirname string, deep ...bool) ([]string, error) {
 	fileInfos, err := ioutil.ReadDir(dirname)
 	if err != nil {
@@ -79,10 +91,14 @@ func listDir(dirname string, deep ...bool) ([]string, error) {
 	for _, fi := range fileInfos {
 		filename := path.Join(dirname, fi.Name())
 		if fi.IsDir() {
+			// 与 go 命令一致, 忽略 . 和 _ 开头的目录以及 testdata
+			if strings.HasPrefix(fi.Name(), ".") || strings.HasPrefix(fi.Name(), "_") || fi.Name() == "testdata" {
+				continue
+			}
 			list = append(list, filename)
 			if len(deep) > 0 && deep[0] {
 				//继续遍历fi这个目录
-				dirs, err := listFile(filename, true)
+				dirs, err := listDir(filename, true)
 				if err != nil {
 					return nil, err
 				}
@@ -192,40 +208,11 @@ func toSnakeCase(str string) string {
 	return strings.ToLower(snake)                             //全部转小写
 }
 
-func getImportMetaExport(imeta *meta.ImportMeta) string {
-	if len(imeta.Alias) > 0 {
-		return imeta.Alias
-	}
-	return getImportPathExport(imeta.Path)
-}
-
 func getImportPathExport(path string) string {
 	index := strings.LastIndex(path, "/") + 1
 	return path[index:]
 }
 
-type refExpr struct {
-	scope string
-	name  string
-}
-
-func getRefExprs(v string) []refExpr {
-	if strings.Index(v, ".") == -1 {
-		return nil
-	}
-
-	var result []refExpr
-	reg := regexp.MustCompile(`\b(\w+)\b\.\b(\w+)\b`)
-	match := reg.FindAllStringSubmatch(v, -1)
-	for _, v := range match {
-		result = append(result, refExpr{
-			scope: v[1],
-			name:  v[2],
-		})
-	}
-	return result
-}
-
 func hasGoFile(dir string) (bool, error) {
 	if !gfile.Exists(dir) {
 		return false, nil
@@ -247,14 +234,6 @@ func packagePathToFileName(root, pkgPath string) string {
 	return path.Join(root, strings.Join(part[1:], "/"))
 }
 
-func getStructInnerFields(v interface{}) []*parse.Field {
-	switch n := v.(type) {
-	case *parse.StructType:
-		return n.Fields
-	}
-	return nil
-}
-
 func isProjectPackage(module string, path string) bool {
 	return util.StringStartOf(path, module+"/")
 }
@@ -266,7 +245,7 @@ func getPackageNameForFileName(filename string) string {
 
 func formatToCodeString(content string) string {
 	content = strings.ReplaceAll(content, "\r\n", "\n")
-	content = strings.ReplaceAll(content, "\n", "\\n")
-	content = strings.ReplaceAll(content, `"`, `\"`)
-	return content
+	// 代码中可能包含字符串字面量, 需要完整转义
+	quoted := strconv.Quote(content)
+	return quoted[1 : len(quoted)-1]
 }
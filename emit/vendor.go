@@ -0,0 +1,71 @@
+package emit
+
+import (
+	"path"
+	"sr/parse"
+	"sr/util"
+	"strings"
+)
+
+// isVendored 第三方包是否按照 Option.Vendor 复制到调用方, 标准库的类型总是导入
+func (r *typeResolver) isVendored(pkgPath string) bool {
+	if isStandardPackage(pkgPath) || isProjectPackage(r.module, pkgPath) {
+		return false
+	}
+	for _, pattern := range r.option.Vendor {
+		if matchPackagePattern(pattern, pkgPath) {
+			return true
+		}
+	}
+	return false
+}
+
+// isCopied 包中的类型是否复制源码, 否则调用方需要导入这个包
+func (r *typeResolver) isCopied(pkgPath string) bool {
+	return isProjectPackage(r.module, pkgPath) || r.isVendored(pkgPath)
+}
+
+// vendorDir 查找复制的第三方包的源码目录, 并记录目录对应的导入路径
+func (r *typeResolver) vendorDir(file *parse.File, ref typeRef, pkgPath string) (string, error) {
+	dir := parse.PackageDir(pkgPath, r.root)
+	if len(dir) == 0 {
+		return "", formatError(file.FileSet, ref.pos, "cannot find source of package "+pkgPath+" to vendor, forgot go mod download?", r.root)
+	}
+	if r.vendored == nil {
+		r.vendored = map[string]string{}
+	}
+	r.vendored[dir] = pkgPath
+	return dir, nil
+}
+
+// packagePath 文件所在包的导入路径, 复制的第三方包的源码不在项目中
+func (r *typeResolver) packagePath(file *parse.File) (string, error) {
+	if pkgPath, ok := r.vendored[path.Dir(file.FileName)]; ok {
+		return pkgPath, nil
+	}
+	return util.GetGoFilePackagePath(r.root, r.module, file.FileName)
+}
+
+// isVendoredFile 文件是否属于复制的第三方包
+func (r *typeResolver) isVendoredFile(file *parse.File) bool {
+	_, ok := r.vendored[path.Dir(file.FileName)]
+	return ok
+}
+
+// matchPackagePattern 与 go 命令的包模式相同, 以 /... 结尾时匹配包及其子包
+func matchPackagePattern(pattern, pkgPath string) bool {
+	if pattern == "..." {
+		return true
+	}
+	if strings.HasSuffix(pattern, "/...") {
+		prefix := strings.TrimSuffix(pattern, "/...")
+		return pkgPath == prefix || strings.HasPrefix(pkgPath, prefix+"/")
+	}
+	return pattern == pkgPath
+}
+
+// isStandardPackage 标准库的导入路径第一个元素不包含点
+func isStandardPackage(pkgPath string) bool {
+	first, _, _ := strings.Cut(pkgPath, "/")
+	return !strings.Contains(first, ".")
+}
@@ -0,0 +1,308 @@
+package emit
+
+import (
+	"fmt"
+	"go/ast"
+	"reflect"
+	"sr/parse"
+	"strconv"
+)
+
+// wireChecker 检查参数和返回值的类型能否通过 json 传输, 会进入本项目中声明的类型内部检查,
+// 无法传输的类型报告错误, 传输后会丢失信息的类型给出警告
+type wireChecker struct {
+	root     string
+	resolver *typeResolver
+	// 严格模式下传输的结构体的导出字段都必须有 json 标签
+	strict   bool
+	visiting map[*parse.ModelType]bool
+	checked  map[*parse.ModelType]bool
+}
+
+func newWireChecker(root, module string, option Option) *wireChecker {
+	return &wireChecker{
+		root:     root,
+		resolver: newTypeResolver(root, module, option),
+		strict:   option.Strict,
+		visiting: map[*parse.ModelType]bool{},
+		checked:  map[*parse.ModelType]bool{},
+	}
+}
+
+// checkFunction 检查方法的参数和返回值, 首个参数 context.Context 和最后一个返回值 error 不需要传输
+func (c *wireChecker) checkFunction(object string, fun *parse.Function) error {
+	for i, p := range fun.Params {
+		if i == 0 {
+			continue
+		}
+		err := c.checkExpr(fun.Parent, fieldTypeExpr(p.TypeRaw), nil, fmt.Sprintf("%s.%s parameter %s", object, fun.Name, p.Name))
+		if err != nil {
+			return err
+		}
+	}
+	for i, r := range fun.Results {
+		if i == len(fun.Results)-1 {
+			continue
+		}
+		err := c.checkExpr(fun.Parent, fieldTypeExpr(r.TypeRaw), nil, fmt.Sprintf("%s.%s result %d", object, fun.Name, i+1))
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// checkExpr 检查类型表达式, params 为所在声明的类型参数, where 用于说明类型在哪里被使用
+func (c *wireChecker) checkExpr(file *parse.File, expr ast.Expr, params map[string]bool, where string) error {
+	switch n := expr.(type) {
+	case *ast.Ident:
+		if params[n.Name] {
+			return nil
+		}
+		switch n.Name {
+		case "complex64", "complex128":
+			return c.errorf(file, n, "%s cannot be transported by json (%s)", n.Name, where)
+		case "any", "error":
+			c.warnf(file, n, "%s loses its concrete type after transport (%s)", n.Name, where)
+			return nil
+		}
+		if isBuiltin(n.Name) {
+			return nil
+		}
+		return c.checkNamed(file, typeRef{name: n.Name, pos: n.Pos(), end: n.End(), expr: n}, where)
+	case *ast.SelectorExpr:
+		x, ok := n.X.(*ast.Ident)
+		if !ok {
+			return nil
+		}
+		if x.Name == "unsafe" && n.Sel.Name == "Pointer" {
+			return c.errorf(file, n, "unsafe.Pointer cannot be transported by json (%s)", where)
+		}
+		return c.checkNamed(file, typeRef{scope: x.Name, name: n.Sel.Name, pos: n.Pos(), end: n.End(), expr: n}, where)
+	case *ast.StarExpr:
+		return c.checkExpr(file, n.X, params, where)
+	case *ast.ParenExpr:
+		return c.checkExpr(file, n.X, params, where)
+	case *ast.Ellipsis:
+		return c.checkExpr(file, n.Elt, params, where)
+	case *ast.ArrayType:
+		return c.checkExpr(file, n.Elt, params, where)
+	case *ast.MapType:
+		err := c.checkMapKey(file, n.Key, params, where)
+		if err != nil {
+			return err
+		}
+		return c.checkExpr(file, n.Value, params, where)
+	case *ast.ChanType:
+		return c.errorf(file, n, "chan cannot be transported by json (%s)", where)
+	case *ast.FuncType:
+		return c.errorf(file, n, "func cannot be transported by json (%s)", where)
+	case *ast.InterfaceType:
+		c.warnf(file, n, "interface loses its concrete type after transport (%s)", where)
+		return nil
+	case *ast.StructType:
+		return c.checkStruct(file, n, params, where)
+	case *ast.IndexExpr:
+		err := c.checkExpr(file, n.X, params, where)
+		if err != nil {
+			return err
+		}
+		return c.checkExpr(file, n.Index, params, where)
+	case *ast.IndexListExpr:
+		err := c.checkExpr(file, n.X, params, where)
+		if err != nil {
+			return err
+		}
+		for _, index := range n.Indices {
+			err = c.checkExpr(file, index, params, where)
+			if err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func (c *wireChecker) checkStruct(file *parse.File, st *ast.StructType, params map[string]bool, where string) error {
+	exported := 0
+	for _, field := range st.Fields.List {
+		tag := structTag(field)
+		if tag.Get("json") == "-" {
+			continue
+		}
+		// 嵌入字段的导出字段会被提升
+		if len(field.Names) == 0 {
+			exported++
+			err := c.checkExpr(file, field.Type, params, where)
+			if err != nil {
+				return err
+			}
+			continue
+		}
+		// a, B chan int 中只要有导出的名称就需要检查类型
+		transported := false
+		for _, name := range field.Names {
+			if !name.IsExported() {
+				continue
+			}
+			exported++
+			transported = true
+			if _, ok := tag.Lookup("json"); c.strict && !ok {
+				return c.errorf(file, name, "field %s must have a json tag in strict mode (%s)", name.Name, where)
+			}
+		}
+		if transported {
+			err := c.checkExpr(file, field.Type, params, where)
+			if err != nil {
+				return err
+			}
+		}
+	}
+	if len(st.Fields.List) > 0 && exported == 0 {
+		c.warnf(file, st, "struct has no exported fields and is transported as {} (%s)", where)
+	}
+	return nil
+}
+
+// checkMapKey json 只支持字符串, 整数和实现了 encoding.TextMarshaler 的类型作为 map 的键
+func (c *wireChecker) checkMapKey(file *parse.File, key ast.Expr, params map[string]bool, where string) error {
+	switch n := key.(type) {
+	case *ast.Ident:
+		if params[n.Name] || isMapKeyBasic(n.Name) {
+			return nil
+		}
+		if isBuiltin(n.Name) {
+			return c.errorf(file, n, "map key %s cannot be transported by json (%s)", n.Name, where)
+		}
+	case *ast.SelectorExpr:
+	case *ast.StarExpr, *ast.ArrayType, *ast.StructType, *ast.InterfaceType:
+		return c.errorf(file, key, "map key must be a string or an integer to be transported by json (%s)", where)
+	default:
+		return nil
+	}
+	modelType, err := c.lookup(file, key)
+	if err != nil || modelType == nil || hasMethod(modelType, "MarshalText") {
+		return err
+	}
+	if nt, ok := modelType.Raw.(*parse.NamedType); ok {
+		return c.checkMapKey(nt.Parent, fieldTypeExpr(nt.TypeRaw), nil, where)
+	}
+	return c.errorf(file, key, "map key must be a string or an integer to be transported by json (%s)", where)
+}
+
+// checkNamed 检查本项目中声明的类型, 其他模块的类型无法检查, 视为可以传输
+func (c *wireChecker) checkNamed(file *parse.File, ref typeRef, where string) error {
+	modelType, err := c.lookup(file, ref.expr)
+	if err != nil || modelType == nil || c.checked[modelType] {
+		return err
+	}
+	// 自定义了序列化方法的类型不检查
+	if hasMethod(modelType, "MarshalJSON") {
+		return nil
+	}
+	if c.visiting[modelType] {
+		c.warnf(file, ref.expr, "recursive type %s fails to transport if its values contain cyclic pointers (%s)", ref.name, where)
+		return nil
+	}
+	c.visiting[modelType] = true
+	defer delete(c.visiting, modelType)
+	where = ref.name + " in " + where
+	switch n := modelType.Raw.(type) {
+	case *parse.StructType:
+		err = c.checkExpr(n.Parent, fieldTypeExpr(n.TypeRaw), typeParamNames(n.TypeParams), where)
+	case *parse.InterfaceType:
+		c.warnf(file, ref.expr, "interface %s loses its concrete type after transport (%s)", ref.name, where)
+	case *parse.NamedType:
+		err = c.checkExpr(n.Parent, fieldTypeExpr(n.TypeRaw), typeParamNames(n.TypeParams), where)
+	}
+	if err != nil {
+		return err
+	}
+	c.checked[modelType] = true
+	return nil
+}
+
+// lookup 查找类型引用在本项目或复制的第三方包中的声明, 其他情况返回 nil
+func (c *wireChecker) lookup(file *parse.File, expr ast.Expr) (*parse.ModelType, error) {
+	ref := typeRef{pos: expr.Pos(), end: expr.End(), expr: expr}
+	switch n := expr.(type) {
+	case *ast.Ident:
+		ref.name = n.Name
+	case *ast.SelectorExpr:
+		x, ok := n.X.(*ast.Ident)
+		if !ok {
+			return nil, nil
+		}
+		ref.scope, ref.name = x.Name, n.Sel.Name
+	default:
+		return nil, nil
+	}
+	loc, err := c.resolver.locate(file, ref)
+	if err != nil {
+		return nil, err
+	}
+	if loc.builtin || !c.resolver.isCopied(loc.pkgPath) {
+		return nil, nil
+	}
+	model, err := parse.ParsePackageModel(loc.dir, c.resolver.option.Tags...)
+	if err != nil {
+		return nil, err
+	}
+	return model.GetType(loc.name), nil
+}
+
+func (c *wireChecker) errorf(file *parse.File, node ast.Node, format string, args ...interface{}) error {
+	return formatError(file.FileSet, node.Pos(), fmt.Sprintf(format, args...), c.root)
+}
+
+func (c *wireChecker) warnf(file *parse.File, node ast.Node, format string, args ...interface{}) {
+	printWarning(c.errorf(file, node, format, args...).Error())
+}
+
+func hasMethod(modelType *parse.ModelType, name string) bool {
+	for _, m := range modelType.Methods {
+		if m.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
+func isMapKeyBasic(name string) bool {
+	switch name {
+	case "string", "int", "int8", "int16", "int32", "int64", "rune",
+		"uint", "uint8", "uint16", "uint32", "uint64", "uintptr", "byte":
+		return true
+	}
+	return false
+}
+
+func structTag(field *ast.Field) reflect.StructTag {
+	if field.Tag == nil {
+		return ""
+	}
+	return unquoteTag(field.Tag.Value)
+}
+
+// unquoteTag 源码中的标签字面量, 按 reflect.StructTag 的规则解析
+func unquoteTag(value string) reflect.StructTag {
+	tag, err := strconv.Unquote(value)
+	if err != nil {
+		return ""
+	}
+	return reflect.StructTag(tag)
+}
+
+func typeParamNames(v interface{}) map[string]bool {
+	list := typeParamList(v)
+	if list == nil {
+		return nil
+	}
+	names := map[string]bool{}
+	for _, field := range list.List {
+		for _, name := range field.Names {
+			names[name.Name] = true
+		}
+	}
+	return names
+}
@@ -0,0 +1,52 @@
+package emit
+
+import (
+	"sr/parse"
+	"strings"
+	"testing"
+)
+
+func TestWireChecker(t *testing.T) {
+	file, err := parse.ParseFile(`testdata/resolver/wire/wire.go`)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	cases := map[string]string{
+		"Good":      "",
+		"Warn":      "",
+		"Chan":      "chan cannot be transported by json (Wire.Chan parameter c)",
+		"Func":      "func cannot be transported by json (Handler in Wire.Func parameter h)",
+		"StructKey": "map key must be a string or an integer",
+		"Complex":   "complex128 cannot be transported by json",
+		"Mixed":     "chan cannot be transported by json (Mixed in Wire.Mixed parameter m)",
+	}
+	for _, fun := range file.Functions {
+		except, ok := cases[fun.Name]
+		if !ok {
+			continue
+		}
+		checker := newWireChecker(`testdata/resolver`, "abc", Option{})
+		err := checker.checkFunction("Wire", fun)
+		if len(except) == 0 && err != nil {
+			t.Errorf("except %s no error but got %v", fun.Name, err)
+			return
+		}
+		if len(except) > 0 && (err == nil || !strings.Contains(err.Error(), except)) {
+			t.Errorf("except %s error %s but got %v", fun.Name, except, err)
+			return
+		}
+	}
+	// 严格模式下 Point.Y 缺少 json 标签
+	checker := newWireChecker(`testdata/resolver`, "abc", Option{Strict: true})
+	for _, fun := range file.Functions {
+		if fun.Name != "Good" {
+			continue
+		}
+		err := checker.checkFunction("Wire", fun)
+		if err == nil || !strings.Contains(err.Error(), "field Y must have a json tag in strict mode") {
+			t.Errorf("except strict mode error but got %v", err)
+			return
+		}
+	}
+}
@@ -0,0 +1,78 @@
+package emit
+
+import (
+	"errors"
+	"sr/parse"
+	"strconv"
+	"strings"
+)
+
+// 参数和返回值传输时使用的键, 由 Option.WireKeys 指定
+const (
+	// 使用参数和命名返回值的名称, 未命名时使用位置, 默认
+	wireKeysName = "name"
+	// 使用位置, e.g. p1, r1, 与旧版本生成的代码一致
+	wireKeysPosition = "position"
+	// 迁移期间同时发送两种键, 接收时两种键都可以
+	wireKeysCompat = "compat"
+)
+
+func checkWireKeys(option Option) error {
+	switch option.WireKeys {
+	case "", wireKeysName, wireKeysPosition, wireKeysCompat:
+		return nil
+	}
+	return errors.New("wire keys must be one of name, position and compat, but got " + option.WireKeys)
+}
+
+// wireKey 参数或返回值传输时的键, prefix 为位置键的前缀 p 或 r, index 从 1 开始,
+// 未命名的参数和与其他位置键相同的名称 (e.g. 第一个参数名为 p2) 使用位置键
+func wireKey(option Option, field *parse.Field, prefix string, index int) string {
+	if option.WireKeys == wireKeysPosition || len(field.Name) == 0 || field.Name == "_" {
+		return positionKey(prefix, index)
+	}
+	if isPositionKey(field.Name, prefix) {
+		return positionKey(prefix, index)
+	}
+	return field.Name
+}
+
+func isPositionKey(name, prefix string) bool {
+	if !strings.HasPrefix(name, prefix) || len(name) == len(prefix) {
+		return false
+	}
+	_, err := strconv.Atoi(name[len(prefix):])
+	return err == nil
+}
+
+func positionKey(prefix string, index int) string {
+	return prefix + strconv.Itoa(index)
+}
+
+// renameKeysCode 兼容模式下转换接收到的位置键的代码, e.g. map[string]string{"p1": "user"},
+// fields 为需要传输的参数或返回值, 不需要转换时返回空
+func renameKeysCode(option Option, fields []*parse.Field, prefix string) string {
+	if option.WireKeys != wireKeysCompat {
+		return ""
+	}
+	var pairs []string
+	for i, field := range fields {
+		key := wireKey(option, field, prefix, i+1)
+		if position := positionKey(prefix, i+1); key != position {
+			pairs = append(pairs, strconv.Quote(position)+": "+strconv.Quote(key))
+		}
+	}
+	if len(pairs) == 0 {
+		return ""
+	}
+	return "map[string]string{" + strings.Join(pairs, ", ") + "}"
+}
+
+// sendKeys 发送时使用的键, 兼容模式下同时发送名称键和位置键
+func sendKeys(option Option, field *parse.Field, prefix string, index int) []string {
+	key := wireKey(option, field, prefix, index)
+	if position := positionKey(prefix, index); option.WireKeys == wireKeysCompat && key != position {
+		return []string{key, position}
+	}
+	return []string{key}
+}
@@ -0,0 +1,55 @@
+package emit
+
+import (
+	"sr/parse"
+	"strings"
+	"testing"
+)
+
+func TestWireKey(t *testing.T) {
+	fields := []*parse.Field{{Name: "user"}, {Name: ""}, {Name: "_"}, {Name: "p1"}, {Name: "p5"}}
+	cases := []struct {
+		mode   string
+		except []string
+	}{
+		{"", []string{"user", "p2", "p3", "p4", "p5"}},
+		{wireKeysName, []string{"user", "p2", "p3", "p4", "p5"}},
+		{wireKeysPosition, []string{"p1", "p2", "p3", "p4", "p5"}},
+		{wireKeysCompat, []string{"user", "p2", "p3", "p4", "p5"}},
+	}
+	for _, c := range cases {
+		var keys []string
+		for i, field := range fields {
+			keys = append(keys, wireKey(Option{WireKeys: c.mode}, field, "p", i+1))
+		}
+		if strings.Join(keys, ",") != strings.Join(c.except, ",") {
+			t.Errorf("except keys %v in mode %q but got %v", c.except, c.mode, keys)
+			return
+		}
+	}
+	if keys := sendKeys(Option{WireKeys: wireKeysCompat}, fields[0], "p", 1); strings.Join(keys, ",") != "user,p1" {
+		t.Errorf("except send keys user,p1 but got %v", keys)
+		return
+	}
+	if keys := sendKeys(Option{}, fields[0], "p", 1); strings.Join(keys, ",") != "user" {
+		t.Errorf("except send keys user but got %v", keys)
+		return
+	}
+}
+
+func TestRenameKeysCode(t *testing.T) {
+	fields := []*parse.Field{{Name: "id"}, {Name: ""}, {Name: "total"}}
+	except := `map[string]string{"r1": "id", "r3": "total"}`
+	if code := renameKeysCode(Option{WireKeys: wireKeysCompat}, fields, "r"); code != except {
+		t.Errorf("except code %s but got %s", except, code)
+		return
+	}
+	if code := renameKeysCode(Option{}, fields, "r"); len(code) > 0 {
+		t.Errorf("except no code outside compat mode but got %s", code)
+		return
+	}
+	if err := CheckOption(Option{WireKeys: "index"}); err == nil {
+		t.Errorf("except error for unknown wire keys but got nil")
+		return
+	}
+}
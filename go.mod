@@ -6,6 +6,7 @@ require (
 	github.com/aundis/meta v1.0.6
 	github.com/aundis/srpc v1.0.5
 	github.com/gogf/gf/v2 v2.3.3
+	golang.org/x/mod v0.20.0
 )
 
 require (
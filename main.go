@@ -19,6 +19,7 @@ var commands = []Application{
 	&cmd.Get{},
 	&cmd.Ols{},
 	&cmd.Fls{},
+	&cmd.Explain{},
 	&cmd.Version{},
 }
 
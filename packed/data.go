@@ -3,7 +3,7 @@ package packed
 import "github.com/gogf/gf/v2/os/gres"
 
 func init() {
-	if err := gres.Add("H4sIAAAAAAAC/5SXeTjU+/vGP3YxNNmXI0NkC2PSZPvSiMKEUY0WlXXKFhpDdhWphkGWqXSmKeFEyHZaHZO1IkZ2yq4xwljK2uB36Vwdyxk6v/ljPjPXzPW6nut5nvd9v28UkoNTBOAFAOD27su2wKoXGNgC+GC93THOOE03LxwG6+XoiT7KBbBBqEouKCQP7+o/r2CerMOIscBoenqfc3P+AbPYFDa/Dia9IUzTF+vzN7F9EyIvwCd+ZQ1RaXPijzeNc94auADcD3oTQ9kl27bW69NTYaMx9u+CX8XB+2NEVWdlCaqmeXChZzcE2tyCedkjiAdgwjeOmqleyGtzjjP1Do2ZZsMbWn2YVsfLvXjVkTMqhQgdx/eFMGl77at9lRcLKe3aVMdctUhuUanevCs11j5e80I0zdhSt06sjsHVxMZjPrgAJj6YEL47eInSjoOPms5ZafO2fFMkYVo9+iEkS6V3Th+G3F0nbgrJaNx6cVSQIHTe60NIvOLOi/x3St7rPzCJqEIST+oW+y9KojPVMlOYOc2qxVoHpIdlhzV4VXBzyb6hsLvsCi2SmSmQXpeDXUP5NxnuzwfSGVitRxYdxfuVEu4+4W1koB/e1fP+wixf+tpxXfArTXDLlaakHJPPjPgGuLfFRdL7jJh9LRGqCNWhw9zR7Tin3vvAVVmVRpWH4petw6IIRYIESh6u0TXvE0hVMZjJaZQRU5uBLDjpjpFprqpkfhsYcF380kcfAfGZKZvxSiDceNJt+ZCDc1hyEOcw8r3e7qQ6DnzZVvNE6oKc3kR9K6rXEaUoE+EF5Ztm3q3E7iJ3KL+9dP8WQ8qJMWfA5N7+yAkGRmNg9IqpU1urMZeqwEbcpntas4gCkhANl9GxxWOXEnL2QoeuJmDwVfULCfJy0mmLAXBSUwdnvkQ3gk3rYsXAxYDA3JqL9BC+nRWHucU9lmzGUXi44vXvfoOzgd5pWL+UEPO2aB7/J+mRKnYGeebccvePP2KqqaOlpC8Z7Z8pi753XfB8NcysIDIf9Oj1nK+IaEplw44XcnXg1sWScOJUirpQECg4GNwXVvCC1z0xilJvWT27ze+Y5aGz+ftAVS8lhjO2qhI9E0qv3Hw9acuc2bvNc9fCkrq2M1RN34pjil1qvL8HMjHEu7MkwOQ0ldwiaPlFlvouvFIeUW2+ByJDlCNzqoiUVUweZ78qi0taRNHczCTnzjkjwmJj3ZvpT0131/XRcizbn9Y1ZkkNKVDzRz/4ZMUrcNQ8YQbuyO+v39Jvm17SbPWRNE61ndSqyOIfLTLMrkHk6tV8lerQpPZS7cMWFt6QF+cXrxmFfdeFDzxLlfHp5BIgtybln6MfmOtTuP2pIRGncUV2WMnWmC2gKzSiosmY/fs3iWg5lGSUxQgGP3DG1Qycs/DbPpuprtu2kV38x5Ryqbbqk7o9VfMzB+gqGg4kizCGR+lWXXtpruwbMt05yPRLOZC5fD5h7OfPVbrhUWM8RYwM9trXPd/r/W2+oHRKIaELtMlEfoVci8MteW7N25AX2pXbzzrn+hO73beYDPl5EF4HQkeIkFi9QCRnKu7wY8u4O2fpKF3+332XSo9EC4kjxV4TZh4mjKp3+b8prb2n1svJmSGRtsPerjgUXFXIhEqkW/pmz5fWZiQeT/1GqqTYkKDTFkUqd2odNOMvHDnNgG6/Tbhn8nFe9QS1orHm2fGXt+gmtwPhGA9HqfDxeYHQpAnCqU914pJm10jdM8Z76JaafUKg/GKR3OIUD7S5BcKiz/AG0yMza9xELbmSMNgpCpnfjk5uUHnu8LgSXAalG5XpfaAImofuTm2xs2B+MhyfLRwtg7kWdlZ08Yn5y1N7SDyGUh0gjT+9jgnUp1qYR5Ym5n18h48jCpnstZPMMvXQ6ovuqP6q1bIzKiRoaAY+Olst2ZFa0/5tDACApaVl+TxKb3br5QSAUdBq+dTZulY+lX8hn7hAH4zvav38rVfZJTueYlUOBV8bPDmp/7seTFtf6AiHfKo2Rzv48JPpbZ+MnmLso3inOm1y0tyRYMn5j3WLDEZhIWi7l5hKAYhmJdzkogJJjPLj9y5Vu63J5zDov/st8jhR1IBmugcps0SQkVVJG3/09LhwRU9zPq1FOGlYQCUsg/THOP4QWFcb32uHfxedL3VYnsfZ43m1uzDpL7IXobkxWSRRkeHG6X3B+ka4e7ZTv2fkTNyrEp0jQ7PU7fpwGSsah8A5htItq89BF5uDLMZeFQ8ydLR0YYLv698GZE4mi4jtxDqGllMMFn0k2rqXPiPw5H9aeV0LfnQEAIDL7Ku9jU17rbdJsGqlLwbr7+aM+dG6vk2NLWOdVSpsgvuXq+lQlVyy40vxH6HgKKoYXSYspKD0rTU4yOypIuplXqfdEOLmm9xH6oFv+jH7374SV8moTS+6RoqeGLvjXXLho9OMVzr6o5Osftm+y218fjqkCZUwF/1t0+S4eImDEeU7SgwM+3t9QuCBdPuBJc2copSc7248ZcbMLzLpb619XRDpaJuXEpFdaYcCbQTPhGUr9fc1dezBhbmJ3zudiSaV1ATu/I0yOdITAZ5p2aZxUtT6W0GDfHLiXs99hZT/SUP/gti8b/HLc/I42l1CrjMWuK6LzUFPQOco4GxhWQ4xR9kobuI1v1QIf4wWxVSjdb6q4UuKi726c0xdbPteuz+e20fsD8r8MpqEuzQ2FnRRn9OIVnz7/Hiu6oJ2XJK/vAlCtrX+QVzIjDTssXkvmOtQhH3k7P47Gq/c0dB31b1UYi1s2OB854mabpRfV7gSF/lgGNfPuVPE+DFWbACQtmbuIuvmLspyUD9vM7z1m92P1rOkNmJpYs674f4DkHsdUGZD4HlHL8dzGOwP5simm2mwTjN2/Yr587l6QxWWdSOB6t4Plaigas3KGGIL5Nqse8oTdt3dBzf09JK7ptnwLNdueGvBKeUHY2dc/oxN5WzY79fkGlYec5qIWmgzTyyITO/jFDCHud4oHxJYlKx1eNF5CZ2l5oAuz+0qH2s+ax8Te7BtjKxtLkwY4ZJmvywkDWNkiZ6U5D+R8p5m6UGOOlKapHwhvngUiRAbnEnvcoVbJ35CnPmwr3WaOCDINeWjM91SVDCB1/ufcGH4u74UPcuqIcFTTYk9sjDFHJvTg/eOQlCyBjR6Af5zWq7NZGzdmMb8EkSS2cdMPfvW87FCwJj8/bLTVoKD2ne42nm26hiLBjQOqHrp/1mrwDcP+vrI5wYfyiPBgF9DvGdXoEn2w2TYZ/gVV2Wyzyt6ku8OdQfO05ULcsEnHlAnr9nFZUaNOLjMJsvTMCJvmPNbjL3zjAcIw8HGTYYmnSIyl2XZfi7pzUNTEjZsAFDEsWax1l2TeVZm9mMcdIYyi4mzsYtwbBwmfn5aQiw/N4kWKxhWYWIFc5AF5l/RYgXGKkyswJI2hK2PFj+Jf4eJ1T6p9A8RAJ5epm1OZBktVtOXvXa1dSivoStyA/9/513dDlb+s9IOHx7gv7jRSrXL/rNa8BTWVEvZBMfSjVZXykoxVyp13QL8Uj83ZkmtYRVtxFqvnytAVoq5AuTiA/6zfq70clkxV5/LXWt6eeJXzI30c00fWBzwlbIFQACL487Fvfw7CAAB3RwAgAQtf/u/AAAA//+Ero22QBAAAA=="); err != nil {
+	if err := gres.Add("H4sIAAAAAAAC/5y8c3gmSrY9/HZsG51OOraTjm3btm3bttWxOu7Ytm3bxvecme/+pnvuzDl3Jv+8yZM8a1dqV61aVbVXyYgDg6AAIAAAwABSiBLgly8EACTAxs7azFDfgcbUysHQzkrXQlEBFPBpfoLWQEYcHOLXP/73MGj/AobGwtrYVP//ANZjI6/562+w/y0Yjb2dzd8Rx7/Om/07RAhA5z8hkv05Io29tb65oQO1sTW1g4vD3/AHv86blStuWM8I4Xjfv6FqX/tY7LK5Rx32oLCFQqqFVp/pLvufaPijHlC3tMQoyRIuQjmk5+QI0gLpzYCoM0WiEqKVgiZ4E0dlfug9ry+FIs71Fuzn1l8sHAw83HAmj1S76XHLUfMnLpAxkuZ3K9fBpbPLIJ8BStHH/bc5rjdXwcqtNYLq7Q9o3HcNtkRX4NO8/P1Qznxiquf2+Xwly+WqFrbvpASUUzby3ry+GYRB7QsbaqhaPY1ay8gSKJFtSkxZBkbjD3TYBYMS8ZXLfVVQZkvX6lQ37Og5zp11F78YZ2yCyB+kcT4q3OWNL0WyhShltEbgXpM8X5cgkTZyzhoDy3e5EKRfzYy8N1qA1xQhiY73x5tG3yD293+q12X7CYrJUCXUpwg15MKduZr2mTOb24UJN3p0eClzY6dN9vWyc441D9dljrRAgpd+NZAig0X32YjVnTF17d2R653s7eQIaLraFv/9yul14ovru6aHPiPuBkv80Qr3zpv70KNuqzfN24uj26Pb/TU2Rw2Ad1YqfX+5ACmThzdaRRNSJ2muzkIPNIOONT+4NINOL3HkEh9QvSlWjpY4bS0D2YWa3ZWsbXlOKlbOZoxyWSQMG6ylDSwRnSUV/U56HfjYP2Jgo/PqjN/+RNt+ZfH+qPa8cvf4or3vf9Jr5oiyBlbe7IyIJ5odUwEH7TNVKavvbCibAWxMD+zWGyejGjiz3yZMWUZBMQgLaJ4mmufjB1Xzil8GJYKTyseo1f2WKd4N6qknKu3+04y0fIb62VQ8WMWvNErnSywdMa8wMSfOwMtD5ObuVJnLRd/p+83jGBO6UbR3FrdL4h7l5B6IfKhBk3kY4gWe1ICqlKRQBGrDKBM7WD/cLLNBhT9o/we1ThX+WwzTKg7+iE+QvQ9UF+jz/OYSUFWhR+4lR7CKY7MgKAhi8mzS57SdlO72TEJ/4PizJf8pdtSsz2tZ7Zhpw1hL4sZ2A4zNkbcaMVrKbOIKOJ32mYJr4Xq2+y4VVEuDdZqMdFQh0I02yy2H4cQZw1DKd/ki8DpihJHFRzgUl0SgR3vffGsp3KwjnQV4KJ78ain64XAOkSnQB7tDNltypgVdYHbYWSDsnLccuJxCvdZy5cDvdngCMxQZU1z7OQjEyUcP2ZYFKU8yqjxGSSt7jSO+5wsSbJcoGnxqIyJDVrmARiR/LtmlvBmYn2sSMExnEF0I4GFZJYrzYtN2Do+IcDQ/NG9H9C2feCvmpwixJrY8RR0rlX5Hj4Tf+lLsUIPWIvDmRgz+vpM0mj656vwUGPTQ9g5z6BVxsS5K4v84hQzxs7HhauglUglQi1vNX7h6ijRbv9/Nu5AsO81b8jI8gJKhqWpUWgMzus2wp1m4Qh6/faxJPR/bitc4Oh5925peW/88cB9u29Lww0ob9G1o7Rp4+QTdjd/tufuO+jLHlEaNC+8tQnDpMS9MM3VOJtvNI/0YNBVLvZ5MD1sxVZ+EN8em/duLC7WipzsqIFysFDF51kkk+5UdICMODtF57gZnBgIAhED+ymTP9r8zGclfMZmdjf6vPLZENm9WrqRtPauE5n3/hmJMhbjUu2iCl4+rZILE64QfG3d67SPjoI/Hnrz4APegxS/DOC0GTbkyHQeuJqBYUqEA+VltWQxVMC+Epsegu7F4vN6YHjKeXGQ0fuDh9Slz4ynTPpNw8smEO4lwUhGTzxeJqTGlq99SZa4NpTkZrYtcsZ+2A0Z8xLyatqMJrTY6PevOpbkPGiTfnUw/75jMEl8nq6NWvqSc+ipiF/X+JTQGrX7XUE9mA4Xs2dss391UznYIetYghdzUdmX667CCIR/akGN+gXtu/XRQ1vWwqkjdUGE9Z/anx34zfGhnC8FALv0sbYgWVeveklIkrREJjOQJbg+5YgLpMI6fTwuEC63dBazrPvRopmQyyzuRCaJhs1YvlAaUVYZQfFDYAdU9HMD2uDEu4c9oAaPmZGHK1Qj9eU4XkoOthL5dbS09CxPB2mtLL69Hm+/hXfHG/mvg7hvvzB53Nh9jH+vtr1drO9ob7W5drUntjx5Jjol1aolEx50dIYbwjhpQ+m8QEq1H73gqAaMUKCkqC/RfWq+Q1qG6Y+4wxRMZkwrq1jW11NQOKytoyst5wD3y4NOeecmhS+KQ5E8YjKwfEGC2wPUdM9XIG+s5JK1sF95yL6MS6KBtmm1XdLOE1M0hGc77pLwtsFPmSUH40pctTQKR5DmbLHFbCCshXJKOIMjb1xTr6xBz5nubhaRud/O+fMS4EjgLex/5oBD9dOekFsTKITZ7HhUfytaYoKdRXk6tQx1zzEn89MMJlEVJsTkwSe0YkQSxPBq50enGL/mTbvoYBXbVfV0MM/xrB/LhOBkDvyefKVtRBN6ntoicRIdXeCID2uNr0LrkjCOkqkkQXRCv3WBdEEbIK8FdzONMrk+uHZ2FBUuqbihPKV/pv0EzMGxGba12fUo8dRX0EEXlxaCZBOwLaM+K8eqX+YgubCqgPYPjfiH9agWf6HUNQS2QjRtIH2oQ0EOek8sneUvBedDC1QTwZucPx+fKVp/1ddf4vpvKTsgJqfrVHIlJR+PEFO1hw4PT4/7ofeIDl8uN2eMWGmPBipU+zP/iAsb5gm4if2eqgH3EZ1P2M3Ij1HGToNix6T6BiXrldEV1JEILXxrBysz8ypE9I05w4Nu7TtNgRVLaEcYH5oNqBFhf7H5QaOn70dzmVvLFuRezx1traqX3TU3/j5rwpvR4auqcTBNBiafXEmAhb/bdirrcaeDsTnZijUDLic5gVTcLEGH2IXNl4LmzKYRMCAjMPDq8qO+OHJgZiKEkfgpXZ4lTox6slaIX8b725U46qy0uc2coagoKhA46o/u9UyromT8ENByv8hzYKy37E+5a/VjMGJ2Ww6J4dmAXTprq0w6SghE02hpsV5hLzepJrn/M5tp/yb53i8mziMT2i1qVt2IVasinzU8AGf0EWZSrXdxLN+lqFhgHLl8Q3sxnCttre8rlYpe1LcVtYSO5aiiXMEOP2xZRZDzWtDc5icxHG2scV56uIc9r0xwqmtyTdzqX5HqWMv0g/0qjamvJ7IopMwlhxLiewpYvLEJMn47TSpn5uX9h8rrfUeLMsK71aZaWdzFtWhNMuSqyshy4Z59AM5pHpRwifb+GBz0Ni/I6KWVktTBdk4iBtw7c4KwZ7UN+sNw172SCirwxB9zZG+P1PfuNpeVlg3PR/VXv8TC9jeZ8pnHmI5vZ2oS/43V3LV7TzuaRrdOdM0GV5yPPVlc5YE0DGbV0GetYzH/uLMeLP0joAVP5an1OCH6BmI3f8BFlKtueUSDebNYkjz+MtUHNJbsFokHjCvmEIwD2O44jQTCSIQ8afoa5lX3YDkT+/fTQLsJeK+NhdX0lyn01BZ1EpQP9Wr2nrmNk+cQ7BkBGHBwi8Toc3wUUALiE+ZXV/1k+k/4Fqzu42hja/0rrfwjq8mg66R4emMCDZ2UVTMw03NCST4iyab4WEGTnmZALdeNH45gEsx9RWN8nhTAxnhvYuV88aSSvtoz8GPsUzTHN0eAKLGxXdtQvwZRPcOotH8RHoVZRiLiMXH8GrPT7k8zIjGH4swCW3fv1WvTzJ1ofcLREMfODxkm9erb7Qvt4Zf3jsUhKnX4kvhTY6sVDoWGzXXKf8oFzLWWz0R2rhT6+2zp7adhELHAPiEqQs0aNMpxzH2tHILohqd9nBest6ON2SBtgko1pVBc4fLpiCqjSnNXwKJL3O1mzP0rd7Q+VJGftnvj7urjohb8DAADMgf5sA4Lxr3rQ3tDOyVTf8C+3IP87IYR/Ave/1ti/J6PLbJUWIWgirejxaihI0Mvve/BaHYYYsKnVd/AKDidByyHMaP6kvgutOvNpDTt1XEK6V48PFsfItzW4OSa4EsNbHNUMu/AZzI1pZduvO5dtvoWMKjh4VztXmPAD+0vTnrAmdu9VJ5gMI+aXEij9ZKF5hPKPKIkbdV/oBfFuJ+xquCLr1fWw992ShtAVEI9c9Ri5Da8hZFXZDjDR6aVfay7Lvy+wWJC6N7WxQ3R8QT5NYmNzRB3W7pA23sNVIRQV3NeFyQZkLMQQGCUivOBm3I4MyhqrmG8wUZs78k+J3R6D8F9bNZUyPxEfJx3GdhjWn9ngIzfDd719poNxtSBG96cJp5UCPqDvLTicRlJ3EOWy8CY6u66WhtriGh2+/l48WG4Q4Bj72EEwosmZ1aDzOWlLqMTuxh3FHUTtaONvaa7owaLh/AQABP9pmlH/ZV7+Z1P459tMT4ffpRTuv8OisdS10jU2tPsb5gT5n200yf5JnpH+FSaNrr7Fr2NHivQPfSZuvSKEwGXu3ZcQzGMRYjiZDwQKkVBHuzkrGJ8Fgp9TgtqTRpC4J9eADyFihl29QswE1SWq0Viiy3iXe5UPrixltjsRknBLE+YZU2hxByqAHlfLsNHanppoyvfU9hZjykdUjGwTIl3cJ4K7n7xFbbkkk5r5CQ3kc5C6/eXRKmeTp/n+Z6GLBU4z1Xu/PQQTtfY9hLCYFQvQBF5EVEb2S0xVPSxyE/z8youAbcelRqYAqLSdGvApjgb/wiMQqR4HLKOXr7W8FcR0uGfWqAT7boX64F7q/GzbTzDr57DeXhyHHVEPUXEH0sErZVfLO1O83k1tbGxqmPf38RXywP1t/rwfkh4ubGzrkSzZMN6cYvONQhdOQS8bIlRkjD464D04Fchmg+CVMnp0fstooCM3foJyVSpZwiYQhiZqFaWjlUoVQ8lSygGO8WWFYfSMR295G2RdcO4DPesjBp72TW3kM+HO9113mmD+7+amwVix1tIuZM9K8T4w3QD1n/0Y4rFwuAopEjnDVGYyYGVzBF5trGeM/N0YJyL795i5kiwwa50dkqY8+NCcT54sS0siXhqYHYsK3cYk+c5tj8HZQStlO+vhU1v4eiNUdpAEBow1mPBXhWOrncquOX4S1G+nBKnOsZ3B6F8cELpQHL+P/FgFc+mlQaKUdSBN3sLiJpXpJjj2R3uC9qo+LCPsgu2CV1k21vFlBcBWAnXR0ysc5o1qpA7Zox4rTr4jS6HvfQPd04B9TNhkR/wqXjyZIUQ/e8YoPrNEoq7gynMKiipT80Dq+jRyeBm9+1jGMSVEYXy1T/EEmSQy7vMqAfOsfWOLtIv7+sShpY7XKks5joeZcbFTT9JTCwxhOtKf55FRejD/VrSUYdTtYs/JxUmn02pHHPq1b6gW+RvKUH++gfHXr8kZkgZpg4zQq9KhHT4WSW7g32qJisdbsgYG7PgqjZ5eFwEDegZTapWiWnvDSnVLSMVEQRTXpsX0ZGFHPKx7rLnTthSJXfgSEUQl9iGzAhOLxQo08VPOnLzU41CClHOWVmJD/FHv31wxYmM8JfwLocLcp6rCb+CHcQR0yH3okymMoC6FeLbaP1lGCVJt4A8rkpYT881ugWn4UyjnjPEr+mhQfO+AaJock+5NhJZfijWgKSHH+MJPRtZUuUx6wOR/VVax27hW4IxUhSc6fo1r98j8biVuNwoQxanH7PhQCrtxiB/LsOjtuKfHzV3N6q31bZOZlc2VwiJU/3iE3pC3a8xFgoSFQHORlM80UuxeqLzxXx2Wfc7DdNpk0SDJKxiX3EtuZhBQJnFJpDZ9Esk/c4icLaqRwL0JtCSVOSeg+QCnB7vRRkqHDDbbsNVMhp7ljDKuiBl7dKxRkGgp1ZXsgKNeFXkwh4rPUxC3krQjEj/yNiDsojZOiA930yLnKmiFrc3M9CpSHvpeqk0YxmU5cU24koSOCHx+M83aNG0TxvYeEOLKyMO+EZA2nhkcS1LKBlqevx+n3bTk6c6V0w3frl8f1kyANDBMbX1lDbGBqhuoyn+zgEKku0s3K+gNC/iD51IqiT1DQQAAL6hfeQ7a8XeeI/9LnrMwtTT97URtgWLerFzF0hp5CfWd40E54w6cEbiHVbXiuStcOc9XSaQ6FuIsGSJx6HEZBnPCxbgfX9aArE53h0cLRhLIdclInjS0gWjISblENOeJIQvzRVvhYJ39fpFuck4P8lv7Q+vqzap9lj31EaMB91JwwQzt7i4pSrGk5lrRN4x0P8RGg87JO/NN9qjvXYm6j26PaBptdmdJQlwV7nLTkbZtPLnFK26+zQEpfXE7z1+QyQxZG6RJ3lBi5QTXOsI3snZqNtYTLHs3TNecw4hfjtIF0wgExFAlPqxcJRgy9Jo3Ua6P+Lg4r1zZLo4F06gL1ilk7iUpNGlx3AkEUfx+aLGEF8KPzVSYXNjeXNJMDWvV0WG3foNKyg4oC674ubd5xGduxK6WZl6x4HccCxX5TAfDo6Jp/t6KW/lZCLu2IpMl8plgg+RdIRSqLOsiCRavMDRBil9GHSIbF5TGOH+5carf4maTeyvae58a60644g4rNghnhbSFuj6SjNYS73z1Boc9np5tpIR9VMmlGueMC/Yzl8n5grtFyaSLM1bFKZOUltAN9Na4bN0H5atpogiLYzbfup3DB4t2bxIdfsBQ1Gu/RfVHkCoUmrfZS4iXCwIlt2iWWuD80M3+u3ivPya8mTTWnXDXtVrTM0Osl7oTJzdmPwZ1wXmMGPgDoja3v6e9N4kGp8InfVatMObsAPEWSJlUMfDFkSBrNmbqFCd8OHHjcDqlKm0QdXdPW5LpsRrL/DxTEtmt8B/v09x+e8acpMhHNbqTSEnyyaXUSoFW1U+e+V4Zo4X7Uz37Rzgdl/sbH5hcXliZHs79j5Ju0VhcY3eEAtshxqDNGtcRe+MuWl6rmC097Vo2RZ62Pciz9TicwvbKmgu2vuyi/RhKC3GoyFzsZlmVIOne4VLzroGB5tZ5kUvzw243mz7allVTDxq04l4btjGShTma6FxNt+n0dx7wI0i0ZV3dr0BspjJp+d8i5B7YnMgDdkcaPtEH3nC2o+rYDollKMwI2JVoO1mKfLOcginYBJKR3N0AFemjWI0FlNleEzy/8+a/s7nYYnBpdxcvWcZArgXiMFGuff+WtPDZILc4WejnzIqFZTNfypfwwGbS6U9gmHItsvdHhLFhonM3fBkKheGpXtgzqF5NiMcMLJ3wC/LBdGuCFNxCFCu69WiWo9c2fhWctJYzwUJvTOjKuXc2dG6N8Fnq9Iz7eCyxYmbQvJY/fn5sHK557ZzdbOoqCmo0sW7TO+NQQcJZtj8zL2uYf0UdbVXkNelPgEYH/wS5X4yFF20GeqpKmJk06Qr2AYhKecTWWRyTVcbObTZfHet08JNmuAwcviYC9gcmpw0b7jwATrSaK4tfrHVTBCfnCNXDMeRMLSsV4W34vhnpuPFAmtwOq0pNSr9fcyW55EAGou6+NW0lwwk1/+DXyNB1ri+l2+rk/9SH8WQQ2yhe0ZijgADicr1w2XKW0zC7nGba7tZp6SNOVeqeIPwV7gv2O2EIWAXslTVqM4ECD1qaT1pNHpCj5utBE6UONL0qKYRDcTixPjJULMhYHoz15fKexjnWdbTH+HRvJZQ/VOwohwpHRR0hkRcosM05Tm9Q+/Yp0QOi4UAs1+7+ds7159BahRF41Fo/SzXyjJNPHmnI/EIBZUBFgTAFXALaJVCx5JSrE5RjCwMlr5BwUadvZVKrwdJ9vC8ALGeec8SlkR+wUBEVQZb+DUMJRTDQ8ER1FFEZKs/cfJWkAqXFEN4XIlu8rNzIVmPBFMK8d9WubAPhYOzaH6pfNVLgzcKnznWbbhlZtfYDJ7NkOFR9p1bOsl3aU2LrT6LoqCkEhqc4zhDq78FXkLBDfMTbavZILBHILprOVnUct4+deOuojt3UENdE+Big3UoEzjJGvcsya3NjMPzoJ+8Q+221Jb6tAQFtnkR5FR6iFKJKs6ZGm/Ypj889j1Knvo+PQzmpd/Zasn/EgKIYlFIShQREQ1j20sMug8FYKzArNgXj5Ham7KgA6SghaljMtuKy3IpfTbe5hNKeaF6nOFt4IDYmHY5bEHG1PFaLn1xqXfeEhIvQxWVG3ivh1bk1IgSpSFsQgbsToNqqAUSk4NoZpCxpvNARhqY5OZjba+vUjWLDpo9jWOu0KgI/lhG/9x1DtYx3iVa39re4yazBSEW58kPNP7jQOTr7RjMnuQQ9GdYHWfKBGuOMiGJc1umtmnT0ZvZmqkeYp+KQ316MCzwTzYhNzCBsuIfqBiK+BFgllkB58yWDWpJqx6CePcwetNHNpWbLDSdroHGNEAz8iMeOymSBNkzeT2Fr16/HdhM9f+1TqOM+TiontYUZfDX6HIGRFpU4VdloCI5/JAEcuLjGtJIMW6fy5KN4imE89uM14uw1qkOA2yp8zMA48urAsEP+Cmz4e4o4HfElaqUzGTTwqJodXbji150PARP+2brdKMjAG6idI3uC2sIl56B0RV90OeJKwtxRGApyHVVDF0XPs9XkRNarZiQUki4n94h0LKlPrNdg9gORzxL578BVxqjd3hcB6jec8BU2+4QeXghK5l35llfdP5b7CnwwyPTuxSMUMQzBUW3BNqjkPzf1F+c3roTOSdXWZr5AyJJIKaCixIMGncLOJK1gmHKSQi5BN9hrY5NFbxEWubfnRcr0/LDPKs+IVrxsHWWD4kGzOGjHQA5sEadNgSGqUtfCgU5ucvkRWX+RRFYS8wELkBEHh1AhZCY5AAcAXJF/1REe/7QHo/xLHfH/f/6qJEbJ583KYyfMbmgReieWilTsavAXpTd7hK+nQKZ3J1f54LBNT5rr378MBSJdn/c5mYcWTop7rE69BmEmEyk5v+RQ2poS6o+l4YImubx3khiDUjdiw/TXgVbeN63gXX6Y2ZNutl/piLbKjhWLyI/B5cjON/lgju47Ims12+NHkJfM1Fx9d1YaxQd3IQm23AE+wSW7d+F42sTShPf71lEDEY1jMlZhuDDycsTMgSgEbrzcgE6hYBCd/zFLgjIiqhmfRKVdCZjIRHuUHi42JOTTZ4waF+zu1Sb7pqWDhve0c8itm6iCH7osBcogGdbhByax3MZXk1Q/GrcD5C6cNb0NtEOeKosDseMK2LBi2bIuAIULSbnuxE+6V30/qPbM25n8limxKa+1mnPg+bHfm5wg5cm9EnXuIgnB0rYJlQ38t9zMZKtlffsEABgC/5obT4f/w6Xp77kxdND9NTF/7JXLFTWlsYVRPO/fwyg+fx3xOhb/zvVltfgWWBh0jyist5qdLv30XKVFn0BBkCCsAsPMfyUGQ4NPjwhlOQGD0ICqP5C7lqHlNdzMyNmyylxOcbi9c9V9fzXf/kLDuPZyzvglwQLNKCQo14fK4msYk4paoGtLcffoQy0PJB19zEiPhten9vExaoOECXUtMoI7RHId2thAqEUlEO4yjh0aaZe3fO8rv4vktzN5G4tLdcPOQqla4kJS+S+b1h1bc0JYHM7IUGya1FSGCnpGskPTDsJ5u5nWBzf2nRv3qt4jLW5j5tKczlQuXoWkDvUh+B23CGW34J+cijXlyF4LCvZjQnm94OodywD+1le5/OU0RZQG2yWCRHeZVqbZIFzTVJIAe4FAaXozAUlk+aJFymlF2x2nIbkSKUrw87UOVLyCcZEef9J9CAdmaI+yLc33fu3M4PbHCnr2x76Lt/Ho5VPHGftowQIlUX6b69ENru2q8SWlgRZWnUyuMpxqMZaAxyJ0i48DnYr9n47tM7oXJ+XLinIMvXnMcqbl3Ei5QlsFTks9RV3CLJR7jcMeVePMy+WyD/DSAgNu73ic66duVm9ujfjQEthgrjAP5yRPsSHv4Rq3zAzWCFCvZ/Yfj9P20UstyO21ZLdVyk/r2qcLajb3xKzuB3duYsfUDbiZKvrLyt0mcZwfi93MnRgJISZAj4Rp2QLGxJIyCg4OIP0Ou/3q2T2C2GXXdFH44KEfR87ewYqlWtbxjuP+Mw0HViGqMVFrBq+3Ajs9zM94bF2KX00WXGopgs4MFR1c3mgjNFgmOJx33BpjwTLIDDahSWAtLiDau4QQVzD5nUlDguq5q4e7BhmfK+wmKy6NWHZ12izvH6Dv3ydPLMGUW7JeSNRPKEpA13oLBIS15AhZxQgjf/pbGOiFnV2iHi1O9iLLyXHrFRnICE8SIvk39E9+rELpY+vywvRJfuHF9Q+AKyHn6pVgZAt3xh3B1AkyM6pPLz202xEwI9nd2XNcId43CAJnpgMNfjVMCZEJJf5mNEbwg2wV6iOqkkRTCGzEtGPvZ/KWFWJt49xPLfVk2LbwCKQkocEPITIlgU5RB0Zmg4L1yTj2EGr4WSEcQYHkwCBTfuFxFThMt+69XB98YxP9ay2LnN7oPaUY2W758GHkvexPf5vISAPMB6bAAEAx+K8Tee2fqh9o/noimxoYWBg669oZ/jqdgQjnzcoV/j6d159QG72KW8+M1tqZ7eag5IjMsAsILfEQSpPu6mt1U5lq9YH4kkTMoAgx+I956JRMEgoec3ViyG1siD4wJyrfaEqXaFrw4BOxCA3MPYzPvx1k2TNgDrbXcscNdQbE85N+ETSyGe06pV81FEQmbm8GXbW1wCUu2ryf5Wyicz3Jklq3dJdjHP2W70NrLZPvE4Fg3n5IC2eaVyARbKexTZZXEEMX/JMsDwlyE446YXJv0xIvPBLadvL6GF1vZ9RaAexM/OKVbmtEUKJUY1yMOBg1z4Ce9pDpbX6mawneq22PhVyv6Bgv29THjTeYLIm/UJqWkdVsE+88LHPjVej7hjuZWfLbOKqNP2qMNzPTC0CyckNFYDj0+ucQVbDfSKogNC+87ApuJRdU7mNk2EJ5GAlNj5RAKq0F9pL2cGN079UMSOvD62hZFpLh9CaYxMQotT3hns+9pP/+ruZV8/bFK4aSOe3hXN8WdIfspyDq2w8TGGVs/3U2DhtcLN6rFnB8fesLy2LJV4V2gbG6oeQv02rZENxMOk0APAGk0pgka/WEryPYxJUJTDz9ux093bEelnt2zFyMGH6LatitauHqJl/MzrweIk0aCGCuJnuNFE8t4KXce95mUiIeS8grj7eX5qu9aE0a+av1rcFdyKivHl2Z3vkG87p0uMgcdd2MHOg6HyPDNzWd+uK1nzPjt94v3s4DwfQsv3LEx0dFC9YSaV2E3gxghdyr21gGm+uoUwtDbS+hrQq0hEgkk2xmW7ill2nZM+DvL0S3HmCnWXyzqITz8iT8+aDlsmJNQGGhxE9GsBFqBE1pZnvoLBViQBpbKKzAs3CHiKrI5pphjBahh2hqdRpUFkmWe9JMjotiMtQd6hU6IkutNLwT++z37SYWlGf2vvdZsXuY3RV1kKAtOUXR36vo+G01isMZfYmAj/HgLXwyjYeKveBugvhjn5PpKtNg2IbX/2IrnjLFQ/Rjvlo85XOsClmZGxu1cmh3TDJGImX+aEDIq5XlpLp0PzYQDihjYTGv3PRFRshypTZ3XBc51ix97saSFPlbT9Ti0nRnVXYPLmNmH2ES/kzO2QdsUgGN/RDOT3pGm30gzDhk57Qtv8/n2Zh5Kq4uE6nxyXqYxYbgvI4pZRv9wUcojqy7mUhjvqaLzAWb/BicuUjfJRJw9mP7MgiBLcsF/B9i6RJJ41SZVbE2VF+9WFObuK5TNFBg0BXyBm3Wo5OkElQG3x/ubpwDcQqjkHFN6fkoH4xFyC5oqwscmKevYoptYLna4dA80gKjK/0dz9QujWAY2NslCytSHW75H01mv7GJL5cWSLzj/I1NPBKXiA6BAYC839iE1vo/lWw2dtZOpga/UckY/rxZeQyvOTIvysDEC1ffV3DRL6frgRlq8IFmREocCZ8LGeINT89H6dnVKUf4uooT0GioyOlABOB/kgryEuS90adKvqLRiy4bJa7XFO29bbjfuHd86Zwf5oIA0pCP0B8kWui1CXIPfhQhQVlgZpkkRX1qag+Eb2i4Wn/ub4Av8/yMxPcgOkhHHBegARzCKrusQ8WZUAdmw8NIrIYL0Y/ZQMvElg9mf6lx3pi9OgHjDaYVQSfqJJcfCOMcyKjhDLHn+knw80Yb0Ug40Oo3+IOTH6/MdTTStvP4oigC5CgoA9xSp0Us9zuCT+d3BQt9jHJgJNwTw7zOrycW0yfLNP5XF/HE79+Ol6YG74MXdF09DF743Z+7xyDJYKKEs4vQJ0gOeojtuB3o8SFapYiPKKtYaJ1BrXV7tHPmzC3XhwjLmK6k2D7GwoNOKHWYPeaK7rPszDKI8HuIw1hDbYaC1C80l25ZUNeqWDr1W3usCV+iDesgKsWvxj2uqBuM2HMJon7OFCVXvSP+LCh8fc49xZsY2Vu5mBmxf/HykRNAdVPqs6aMV+5caYxptJ5kYklNevwAlenc+PsKQ++bzvfjEwDA+5tUpLT6T8eEnaG+tdPvMn4Pb96sPE5THFscp7/Kmauvxy9Yc6IG+GCan3o8VA6kRKaNMsGRoUVTwqjbQAkFUsXjO2M7j5msuHrBdz7TOIqr7/M08UPEG3mn4QrullZAtRMfH+5ZxkFyCZ/PhEkFVRUw+PpTb1HKdHRB7WvO8zkYYIkE5vp2KbPGx/XN7NzWiaLDSRwntG/5gtIG2mJE+fLUNEVAwzEKaZr0RoYJg8j0K/Ow8lEbEIKJ8YMwhY4NWzef84T4EcEYYQ6nuRljtCKnJgmZ8RP00iiIfypnxsmJwDh1HGfaezrMdzxWPsDF3QvPcn5+wmhlx1tvuBsK3n02WVSXXjb27xnZddGf5cJyaoF7Rqd6M5K/8Ka4HboKWl+ezRTPUzo+hRfjH1pUViHq924PA5GGJ/gcR4zPO+qpArMDT2xh4J+kSTojO6RA+W20GMHvO5IADvE7mP3N3JGU2FXEk16o6OGD8cPXqPmo4MaV4mof0d0fODBamccTFQTB7Rx4Tm7P+5aIzzNRwGsrYroYTl7ELJnvfXM9LlJQs9zzt+u8NStzRSeqyWvvd+hrK1iWDXHpcFg15y1xexEb7gkRqf44xQ/OwisVXbrWl88C1tp7XtdeYYxJVUmyrYDGjA/2y3PlYwOhgVqz03ImiYQv4a7pGLKAUDlIjtLC7HCl0ClCocPxkMmaNUqFmG6clJKrCDLB+mHCfqyfPyAjqDgP9GFvxn2Aj764h59nXL8KjlCbuJqA08iR5KrDyfUjF55/Ok+jO/O+uD3/4FOooPSLxVWZHtcoXJLp1oav1GHqeIV0aQ8DsY4jfmL62+BVGJc0UAMCAFBA/qyk6q8Hr4OppaG1o8P/qqtS1rSeXXzqaODuu2kEqelyeWx0BpY3Ba1TQ01CzT0TxFKM8rTQ4Li/XYzyq/lq2QIpSopcYynyBZsqZ49IPYmwTpE5INkj9QfnDVXpQYZ+NMkQJUt6eMTEtwlXLe+JkZK5nKvat6F6LA0ISyBqSAgmfb2eXSqMfCpoao13lG3aW9gmF1SuFjdcas3X22b8j9btvSTTNUZNIEkGWk0+wyXzBj4sf3LxMFQwutQoVFW5DOKXXFEEd0m52iGD7W0hB4rqS3RnVNUklIVwa8L4IFRmc6tDNqaFEsm3vH2ZWlGzSvq4L6GcHftqurTfB9MihLQe0ggltsUTglDcwLGf9jfc2bX3KNLb1n6ud3k0TIlecAxpWx2c1C5th6/HtywtW3mfday1sb8FjywtW13oEL3tr7ye8XtRqHvGV03cK3++b2jce1QKh4EO0UtToRsdnCZROg38tBmF3lRDOAigYqQmnu3V/0k1IoWkkoPEqmhY8EleZa7Xc1dENGMSkBLdTVjpD1Tki8E3aSrjI6EesNiLRuKXS9evUowlVjaX83Lf8nql3eJeNX3hVAMuPoBVyf/+5vVsRvWx5ep8sbHW9va+At5WUXSUfLFLFu8nSblR0IuGRGDCM2MRaa9JEiSRYTpyGjaJJQou1V2NrRyXIjEiZ08I/ZNzpvwjAGA1jxEayNYJWwbmHhBL/KDpbM394YXn33aH792huFzK337Npu2aQEV5cFK7an3UT+zii87vfIF6UJEv/2Kf+fqGJlBniNmtUDC4YDyz13JkF2x/3u4eM051qIM8IPGKURo154tBvf9do0x0sCgKT4ZVqjynST34gxsb1V25RLKYTkjCbK24h2ZCCEcROLm4W+4zfpsO+TxWf/e8rwzUHRg7IxeLqQAUXpmfVjzRhK7Bx4JP581WOE1n0A0sazhOQRKmS1Ip7V4XdKHERq24bMjQNnbXcUDCme0Yo6rZyLSuRuRND6SJD9WkOxUkLfnSdLiDLotn05B+54wKuJiHnhAILnS2ircohxiPweWzBdsHV0tHPn8z/uGTaHNrbypGjCXlZ3WQy/5jqXYuaCuRHsJ7IDvS97a1bwXqWmIXFUYt0Dp9MzHtiHP6xUKHk2snBY3sT2V9tz0aioi4zRfW8KYNi0fnjs0X8y83zjWJ8Efpa7frC0tvme+vnCBQNssO+vIi2qcJDfvWH6meLbHvO9Q0ikfT+adX0a8KVeQvju3XEGd6uf636x1u6uxHLy9Ly7W3OpH5h2pIxSZRpDv9oO9lPfgSqO0D4qWF/obPXNo49ur8KIEKr1/cLAKy2HnKUaXRCVhPyXlqQ/lN8GXmDEpIo0iRre1Y6dCSMFV6hfcDP3lfbmPzy1lIolF+o7Or6/HZ/F7oGl88Dxt/0y6U1zvAiAH1tOr9bF7lgBg1/cQxutSv5+zNre6WVHE6HOceQEETxGAJCx94IbQYYphAD0renJCQWJZSQBlP9qObjE/xDCeBLLx1SjtCOr/bNEg3B47E9sT+NSuV/i1g1vcYoa/QsRauXBdbv0mm3glb9Yc34hXkhXucgS4Q/UMm99fbiuSXg4kc6MU00yFCbInrar84cw04TUUGla8OtMVs1dPd2yL8GyQoUgJAPwuR42uGZrd6E+R8hNHZbPBDUHMZyNfRNnVBVBaw+BG6Z3xNR2m3mtyAx7N/BJIBefhFiuu1DhRPZP/YNt3Rw0BQ5Bez6HAXF3xfwx1FJzJRCY4J5mCTO7/y+57Hn+NZQ6jqhYGIuhbV4AY3T3xbWsqrH4OCHmr6osVDjcrQKyHYKRpLTYnG4LFdnPyOGuxYvcJHaCRMR33lt7jWsbbyTuf+1ihWiWamU9lgyCUJu6mCiUxL20kROJoOk0HOG6ZszlSOQ/DJtuabxgIz80iQbUHYwwH70rGU00G6g1e2o0dT/wzYdFHt07UlXe5UmBVWmDxFH3SuB0qfBT8q+OgovR4Ec66r6ZDIm+Ng0sNM28Erh/orUnAML8nwgnFgIx4NTOsgfdO2QNLIJihdbpRpbGoD5Y+CoKvQIXU6zIi8ijB7JtKggXz/4pmIyB9od36LsonI8t4wUgJhcFT+kN3w/e0pbBSCLH63piSai4GD8PoijRu3WvpzEgftw+cXBplUuNMkNDM/jdv8DdfDbEFaOmj1gsQvxr+P0AhPaHtcbzcoJrK2FwVKIqXbOF5P9IZVxSzOkm+kIGX1iEZsuPlG/lCahACNDlussUezSAglQ0qMNPDYnq3qI7Zb6CLxHabG6PMqMK0J6Ikt8vdHL8FsAoFDpPOFO8cfIitSQeXnZuSRdWnGW1H51jIxSvytAmPYNNjT7KAzSDtU9xyC2/0yZlNVK43lfCvAaZoMlrvcAlhsmR4UIxIwwMN+yK095uUSV3FaCM3eqqcTu+qVvq6+RzfvuzDo8SGoGbEmDG6p2fpp+PGEvyARsqOA4oFVIQj3PnZLGNtrXtdGgCIsYiTXUZ4KPMLuxdmXBo5WgC2AJXwKG1w7XzWi/eCywI86Hd98uKA4bucnJJHTnaVCKwsnuADBOyRmSz8oeMuRdQjAR86zlHT5m5TmWRfqO6yOtdlO2BJW+JGKhKKQ5ev6raJkxDnp3uBrJSmyBGoZbXXgRBhS6PcAW0FGKkM+Ciqhn2j6Wnu0x5GXseb5IOErA6KcWDD3cGQAi2Qi+xBnBa7SWXyZQ1rMoHbiMIh9z16syyUzh4njzRQvgZTXbI9YQTzXcNwBmdMltl5GfDv1942/6Q10UDBeWAgAIADpV73xzfZ3vUH1l3rDSdfC1EDX4bcdVBXhvFmriqX1aj/iu8R7GKQvhkHfdYQXxx5U07h4YG8DAuZRsL9O1XkSyfP5IMVQi4oMSHGlhWioAVkDzlxNWnMFbouVoWhxfnGYxUfq14tbmrAlzTh3mhr5L8XtGU00tbUMNxwMtT9xULwauJmS/W+0UvkCm2dJ8Kl6ZmEkHiORMj4MYPgmylQv34maXI7oPS7fFw3F31yjlbELFIhQoRy346DuFOdKWDi4QTQV6+IOgm7dikvCjBaWoqXPsKh+eANPHHTkal+vJj0u2FL4FpaGKdl2H+0nKF4f/wxT/mGPyr7wrDh+L5q5T75cAp2WKz+iiOTbE3jJRCVnP5xmVmwSC4+rRuG9+ZIFf9XTkcWhHvr5zFmb49yCSoA9/ermQhKDZIuxDj760bnura7lhb/V+6taC+LGijneuUMDWjP3qVAVmkSOUp+fEY9nuBa8p15gARLKhFTploT/MAlo7gZlIG0ZuQQrIIrVCrRSC5qDpYpJhLVCUzFROp2JjfWExRzFmGbyCw/47XnFjexZKdsA9SPmelJA1MF1reHZHnY6ApWlULjw1bVHa5tHGFWe8IbAC4vcjsCKdP6eolfbu30Ne004uExGAuWXSr0dkU7H+nQrSAeojsN4A4C8Bktc+HipWPgjgkSob+ez5ExJYkkVeVfCHfsG5BEIQ2hlvZysDnKR0IjrJIziD048HGSLQfROEqtAoPqApNrSPFZm1ktz1hEuMIMoesThDDZaQ/XRzK85e7z+iNVUWwT8fXtcsOnt0F0JRLMMbpoC+Fqq56seQFQheNROTlpXm37RrfB0j4IeTtmUAuIPfR9xNJJ0G0uL4J+3ETi1Q5PnJU3wrB21uUOsuM5fXVjenXLfrqnZ3H/UtmKsqfqymQLcbvfx2Gk+N8A7N2EgoyLbsalDk2A/tLS0nPVkmpyptrZRVle3L7vGfR71J1rnYE3Vjr637h2pyhP8lIZCsDrU3aVAE7jAKxPomPWRNvmFqwR8swn/zlIur9YeTCYC4CAcPpuW8DPyJvQOE06jxrF9RCZRQyqAtf/h+OZ+C7FpTA12e8zfxfBpt4uX0ZuyEoLrzXPmyhSzSTa/fu0+OnqnY2LA2xWH6rNjVHReW9UU42gneT8o+8bzou1I86dAiciryWAG495bFZupkiOwYwqUcTStguwTi9FmjpmcZl1PmXzHtU1EPk+8C6UfAYG0xxUPoiK9ST96iZt1VbKZNRlzum67MwOBmqQiM8n5xhzNNKjFOGcGQGrtS+WuzQaHYXQ+iTE8oUpMMiLTWtl/E0mxYnqfscLizczcc7nRfb1wPzz4znm+7nLTGXA/spAZWSR6eomRtBN4pJ5fmaUThRnnD+vr2gdCUuAImd5uHRlY9xkivkaRukQeOQbK34JoZSCJtGAqx0pVIyLt2g0Y/YvHgYGK3Kf+z/Ehk/tCiM6hzgvLwROEpI7ZF4I0F9+B1/qM1/K9C30nYvWN7lUiF+ZXqj7bAJwa0JaDtfvgDQmzLDiMD08sTp21N7hVNbY2z8BJHbStBeDbxxzCOzxwPN8MXw7RuOn1TcHk4do+BcNvR4+IbyduQfNiSkE3M+liCC+I5g8ekBRxRAviNx9Jujxth8sXzfBIP7ogqHDSVY6Kyuh3ACmP9vzE6V2ZyFvTB7mb57t9CxsNtAu4jomabw/dj1bNaO8JzCSpDGjTRSibxkQL8XTiiQHNZtc1bU5mM1L8LuA3OopMqb6PCASDTBoWSyMtjvOB0RQyoM7iirTvFWW90cXR6vWyO+Hds7vXPMsNewHZWTBKMt5D8QN6RUnGEQtIsKlVzLhmDvhK388Scrhp/xs1MQ/uCDqO8CgmMW0kiAOqUbFe4rgE3ye+DtGlwwwQ0e8SfH4k11B6q/qpX2p9dFq2OELdGcxwBM99nOm1FFxQfdJjFaljhHLyRxsXBUVdgy5oltHWfSQNTWs8W1HP1pDWRRcB8vvPYnXty8IQU89yt9n+JqbRyQrEvezR22P+JndaB2XeocfhZ5sOfpgJRF5OhV9kChO7yBMZjCelfWUofzCpA0BJJWfkEgWRDlxWQIyKR+/5BaAw3wTc0pIZzBRRVBNnWjOUNEA3hQueeAQiHmsoRw7BxcfIZBneOA5eDIJ4B266eOGzcEHB15FDXgKam+mgxgdFkjjX0WZEm6lmTpr1bZ6xp8KWQw5Vy39Mfm3WjzCJp5SG0ocNt4w1caO28WWU2WAz9aA08cdz8YWvjumlDt1QQD+R4IFawEWu1HU04OGrI0NfvptMM3mMwiZ2Dxs3kJ4DXMCqO1Q4I5pZQLhr0blHEu2KFXp8/VbwsN1QPo3Zk8BY7Xi2lvNoBV0xuFMiZuzmgFl9jrBiJIqDG7N7xbivi6BAliFhgrcuBVhdUhOxHVFuhD9oCaeROHzdIU3j7l9ORg1+C8wfrOAkxZYob+g9JI75cinWpliZwF7gt2CskqjM94kx8nsRGlY2nYsRQ9dAP2mdwbE+H9QkW9tkrxXDpCV0rAVhBTTcrH8YKb9siG4m/5yjk+9HCUybHh/Nh9fhI2/Id1yhVn1g843485DMwGlGqgpY2XrK9YBqQkeitU1rUdpz+ZWf4xDTIFRXSUmbjL7QKzRFWe3NP501p/Pv6bd5qGaVKSGJXjxHFLpAyEqDyOfC44eyaom/6rfPIcBSUH92c86IMUnwvPZLTubOEE059JmAClUe5cp97MvNtLElZtY/KI/M7nPIRh/suxwKZ1MHkdZabQ/Jj0863IlV359MO1370hNwaxBDNFd3dUpjHGsROLL0zMIxEQKUgknuKiEr+DOFEFHpoJRS30j8mJfI03MrGMcL3ZrGP93hsEQutPmsZ65uZR8lj7w8i68SbTRhjRXwUyqGzXlqmGRa8rBMdSbVxZJTTbOXfntvzcQVlSI6nyQjqkD/Ft+ZKYc1lUq0WEjB/zqhNACW/HiqzlIRPM5yMWe+zygTNDjDGljJfJsy207FyNQQ6LvuQFBnw/jPLHb5hzS5uB/qRbcItaKcBo1uLcCCqddkJWKoUWt+QNTiWMB054s936ULuooLBF2hwKyIawf6nUY268PySAffolbkAYSoSjrqO3NQQMLktQV3Tato84ifUyqmUznU9gnM3egivkvvr3AsUq0eLZIVILNlmMxmIES3SZDPCzPOMnm4z8AgM8ozhBmTCKdqNVFVEF0Bxwffi0uy+qLeyOgw8I3EKEhcqryjT3NftZrV4metLVJgOqFoJY5AiIJOf5eITTK6xSIhRB0iT3kRGcPnheIV1N1Y1Pgid4VOp4ZwWdsfijc5UtA9OVoQuurDYqBOdAVFkTRVworfAoWMau0PVrlvlykNDdFyUBJdJYYsrJ33GUrs29jEu7faQvZ0dlSwl5JN0XVNLnRBrGd1KdNHPPZDuYf4sBU+l+eXsyOQF1Z6/Mn4s6dzZ+8QZTTpc0pbe/gjvA29s9vcLQ7bicSR9kmVhE8eYdEPpKEsJgFcu9Le+wyLUF2c8yCwM15KEAOqqkZIDJ5naN3SObxe8OirfuYFC4z4WA+DsksI0bNJYF8349gUD2Y/4AAy4uAQDT8Xbo2hAIB0LABARvzfnfv+dYmAs6md4f869I3pMluRQAlcYJC2/aD0okRgXmpwSXRAXGaMKaTQH+5tVlIUZ9AjbEnpDedTwypeplSA6ZMhBTWOhZIXO+5ncsU0RekQxBxhlkLXFMTu4nzIuBitUMhxM8arUNARV4FgtkugFICGa7kUeIZddKsDzkWwwmx42Lxa/8SxuP6NdKalTz6bSW1U6BLDmFlMDHcCnT4AiQh7ELvbrknTFRGinRe2dOtlMDUY/gc2D7Nb/nPP620FlkbBzAkH1wR3ZsEMaTZxLatvXIep3yrjppFbbh7N0SM5e/j1lgkaCXFx9FdUoqNH9fubFqyMwvhQtqfqacUPelzhwInZWQ4u6ar1DY2O4WBkpkeOk4/B8fWSd8P7Z+ekV7EB7RtdxnDkZYubfrW+uD4+sPpnXRYBZLXmuoFXNQSx4JW7bWMe058+clCmnYhEuMS5PavM6/SH7AkPSyOaMEBGX4lIiVbLuj3eE9wlwCZS2YPTLr23WEht09gw38uAnDVYubSIlcO7EIkE9BaTcKBIxERFo+MKYy5HQd4wJGMjVlQ4+NQzCtEExcUFdSSt5y9OUrWacIdxk5Gm1UFfCGz4hC+99yb0P/QoPc0zHNkoh03aA6mDVMgN7AUZlTjRQA8OVjRxqnpWmcHAzqa4/15mIrGB3L37CQAwBf7VhgHzT6XE4P8YQ38bIYsU/8pw8QkIBfjfv2DwP9+98f7x+SfvGfx7GLTfYIQBgH9jyPqXYP/qBYN/gCX8W7B/fs/gfxD//oLBr45gsv+HCAAU+O7/OeK/ec/gV/w/DsF/9aaR/IZfDQr4j13Gv6L/0bm/+rZIf0Gv8w2CBvznbrc/yx3Gb909CAP4v1jBfm/tr/Yjwt9aiw4L+M+sYH/WUtTfWloFB/hLN9M/sP6Vf+kfWC9wgD+n4l/cTP/41//wL/1a8U/62zCQhgf8526mX+H/sA38WghI/hs8CwrgvzER/Brgj3rCX6vZKH8L4I0D+O+qC38N8Ue3/1pn8/tMxP4M+C+K5H7F/6Na59ebd5rf8HfxAf917c6vUf64xf/1Lvf3jqInBvx3d/q/hvhDHPx64/Z7CCFSwH93RfzPrPXrIdvvIYApAP/dRd6vIf44rftV/FD9FsKeAfBfnt39c0/9ujj+PqS4uAD/haj6lSn+1RL7P0zxwevHA/gXCy4o2N/5CRWgCw4AdPL88dP/NwBt4JMPN0gAAA=="); err != nil {
 		panic("add binary content to resource manager failed: " + err.Error())
 	}
 }
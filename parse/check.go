@@ -0,0 +1,246 @@
+package parse
+
+import (
+	"errors"
+	"go/ast"
+	"go/build"
+	"go/constant"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"path/filepath"
+	"sr/util"
+)
+
+// Checker 对包进行类型检查, 检查过的包和依赖包会被缓存, 构建标签在创建时指定
+type Checker struct {
+	ctx      build.Context
+	fset     *token.FileSet
+	packages map[string]*Package
+	// 依赖包的目录 => 类型检查的结果, nil 表示正在检查
+	imported map[string]*types.Package
+}
+
+// NewChecker tags 为额外的构建标签
+func NewChecker(tags ...string) *Checker {
+	ctx := build.Default
+	ctx.BuildTags = tags
+	return &Checker{
+		ctx:      ctx,
+		fset:     token.NewFileSet(),
+		packages: map[string]*Package{},
+		imported: map[string]*types.Package{},
+	}
+}
+
+// Package 经过 go/types 类型检查的包
+type Package struct {
+	Dir   string
+	Path  string
+	Types *types.Package
+	fset  *token.FileSet
+	files map[string]*token.File
+	uses  map[token.Pos]types.Object
+	errs  []types.Error
+}
+
+// CheckPackage 使用 go/types 对目录下的 Go 文件进行类型检查, 依赖包从源码中加载
+func (c *Checker) CheckPackage(dir string, pkgPath string) (*Package, error) {
+	dir = absPath(dir)
+	if p, ok := c.packages[dir]; ok {
+		return p, nil
+	}
+	// 依赖包通过 go list 查找, 需要在包所在的模块中执行
+	root, err := util.FindProjectRoot(dir)
+	if err != nil {
+		return nil, err
+	}
+	c.ctx.Dir = root
+	files, err := ListGoFiles(dir, c.ctx.BuildTags...)
+	if err != nil {
+		return nil, err
+	}
+	pkg := &Package{
+		Dir:   dir,
+		Path:  pkgPath,
+		fset:  c.fset,
+		files: map[string]*token.File{},
+		uses:  map[token.Pos]types.Object{},
+	}
+	var astFiles []*ast.File
+	for _, filename := range files {
+		f, err := parser.ParseFile(c.fset, filename, nil, 0)
+		if err != nil {
+			return nil, err
+		}
+		pkg.files[absPath(filename)] = c.fset.File(f.Pos())
+		astFiles = append(astFiles, f)
+	}
+	info := &types.Info{
+		Uses: map[*ast.Ident]types.Object{},
+	}
+	conf := types.Config{
+		Importer: c,
+		Error: func(err error) {
+			if terr, ok := err.(types.Error); ok {
+				pkg.errs = append(pkg.errs, terr)
+			}
+		},
+	}
+	// 错误已经收集起来了, 只在用到相关的标识符时才报告
+	pkg.Types, _ = conf.Check(pkgPath, c.fset, astFiles, info)
+	for ident, obj := range info.Uses {
+		pkg.uses[ident.Pos()] = obj
+	}
+	c.packages[dir] = pkg
+	return pkg, nil
+}
+
+// Import 实现 types.Importer
+func (c *Checker) Import(path string) (*types.Package, error) {
+	return c.ImportFrom(path, c.ctx.Dir, 0)
+}
+
+// ImportFrom 实现 types.ImporterFrom, 从源码中加载依赖包, 只检查声明, 忽略函数体
+func (c *Checker) ImportFrom(path, dir string, mode types.ImportMode) (*types.Package, error) {
+	if path == "unsafe" {
+		return types.Unsafe, nil
+	}
+	bp, err := c.ctx.Import(path, dir, 0)
+	if err != nil {
+		return nil, err
+	}
+	if pkg, ok := c.imported[bp.Dir]; ok {
+		if pkg == nil {
+			return nil, errors.New("import cycle through package " + bp.ImportPath)
+		}
+		return pkg, nil
+	}
+	c.imported[bp.Dir] = nil
+	var astFiles []*ast.File
+	for _, name := range append(bp.GoFiles, bp.CgoFiles...) {
+		f, err := parser.ParseFile(c.fset, filepath.Join(bp.Dir, name), nil, parser.SkipObjectResolution)
+		if err != nil {
+			delete(c.imported, bp.Dir)
+			return nil, err
+		}
+		astFiles = append(astFiles, f)
+	}
+	// 忽略函数体时只在函数体中使用的导入会报告未使用, 这类错误不影响声明
+	var hardErr error
+	conf := types.Config{
+		Importer:         c,
+		IgnoreFuncBodies: true,
+		FakeImportC:      true,
+		Error: func(err error) {
+			if terr, ok := err.(types.Error); hardErr == nil && ok && !terr.Soft {
+				hardErr = err
+			}
+		},
+	}
+	pkg, _ := conf.Check(bp.ImportPath, c.fset, astFiles, nil)
+	if hardErr != nil {
+		delete(c.imported, bp.Dir)
+		return nil, errors.New("type-checking package " + bp.ImportPath + " failed: " + hardErr.Error())
+	}
+	c.imported[bp.Dir] = pkg
+	return pkg, nil
+}
+
+// LookupType 查找 file 中类型引用 expr (标识符或 pkg.Name) 指向的真实声明, 别名会被展开,
+// 内置类型返回 Pkg() 为 nil 的对象
+func (p *Package) LookupType(file *File, expr ast.Expr) (*types.TypeName, error) {
+	ident, ok := expr.(*ast.Ident)
+	if sel, isSel := expr.(*ast.SelectorExpr); isSel {
+		ident, ok = sel.Sel, true
+	}
+	if !ok {
+		return nil, errors.New("unsupported type expression")
+	}
+	obj := p.uses[p.convertPos(file, ident.Pos())]
+	if obj == nil {
+		// 使用类型检查报告的错误
+		start, end := p.convertPos(file, expr.Pos()), p.convertPos(file, expr.End())
+		for _, e := range p.errs {
+			if e.Pos >= start && e.Pos < end {
+				return nil, errors.New(e.Msg)
+			}
+		}
+		return nil, errors.New("undefined: " + ident.Name)
+	}
+	tn, ok := obj.(*types.TypeName)
+	if !ok {
+		return nil, errors.New(ident.Name + " is not a type")
+	}
+	// 类型参数保持原样
+	if _, ok := tn.Type().(*types.TypeParam); ok {
+		return tn, nil
+	}
+	switch t := unalias(tn.Type()).(type) {
+	case *types.Named:
+		return t.Obj(), nil
+	case *types.Basic:
+		if t.Kind() == types.Invalid {
+			return nil, errors.New("invalid type " + ident.Name + p.firstError())
+		}
+		if builtin, ok := types.Universe.Lookup(t.Name()).(*types.TypeName); ok {
+			return builtin, nil
+		}
+	}
+	return tn, nil
+}
+
+func (p *Package) firstError() string {
+	if len(p.errs) == 0 {
+		return ""
+	}
+	return ", " + p.errs[0].Msg
+}
+
+// DeclDir 类型声明所在的目录
+func (p *Package) DeclDir(obj types.Object) string {
+	return formatPath(filepath.Dir(p.fset.Position(obj.Pos()).Filename))
+}
+
+func (p *Package) convertPos(file *File, pos token.Pos) token.Pos {
+	position := file.FileSet.Position(pos)
+	tokFile := p.files[absPath(position.Filename)]
+	if tokFile == nil || position.Offset > tokFile.Size() {
+		return token.NoPos
+	}
+	return tokFile.Pos(position.Offset)
+}
+
+func absPath(filename string) string {
+	if abs, err := filepath.Abs(filename); err == nil {
+		filename = abs
+	}
+	return formatPath(filename)
+}
+
+// EvalConstValues 单独对一段声明代码做类型检查, 计算其中常量的值,
+// 引用了代码以外的标识符的常量无法计算, 不包含在结果中
+func EvalConstValues(code string) map[string]string {
+	fset := token.NewFileSet()
+	f, err := parser.ParseFile(fset, "", "package p\n\n"+code, 0)
+	if err != nil {
+		return nil
+	}
+	info := &types.Info{
+		Defs: map[*ast.Ident]types.Object{},
+	}
+	// 忽略错误, 尽可能多地计算出常量的值
+	conf := types.Config{
+		Error: func(err error) {},
+	}
+	conf.Check("p", fset, []*ast.File{f}, info)
+	values := map[string]string{}
+	for ident, obj := range info.Defs {
+		c, ok := obj.(*types.Const)
+		if !ok || c.Val().Kind() == constant.Unknown {
+			continue
+		}
+		values[ident.Name] = c.Val().ExactString()
+	}
+	return values
+}
@@ -0,0 +1,49 @@
+package parse
+
+import (
+	"go/build"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestCheckerTags(t *testing.T) {
+	dir := t.TempDir()
+	files := map[string]string{
+		"go.mod":   "module abc\n\ngo 1.18\n",
+		"a.go":     "package p\n\ntype A = B\n",
+		"b.go":     "//go:build !pro\n\npackage p\n\ntype B struct{}\n",
+		"b_pro.go": "//go:build pro\n\npackage p\n\ntype B int\n",
+	}
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), os.ModePerm); err != nil {
+			t.Error(err)
+			return
+		}
+	}
+	tags := build.Default.BuildTags
+	for _, c := range []struct {
+		tags   []string
+		except string
+	}{
+		{nil, "struct{}"},
+		{[]string{"pro"}, "int"},
+	} {
+		pkg, err := NewChecker(c.tags...).CheckPackage(dir, "abc")
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		obj := pkg.Types.Scope().Lookup("A")
+		if obj == nil {
+			t.Errorf("except type A but got nil")
+			return
+		}
+		if underlying := obj.Type().Underlying().String(); underlying != c.except {
+			t.Errorf("except underlying type %s but got %s", c.except, underlying)
+		}
+	}
+	if len(build.Default.BuildTags) != len(tags) || len(build.Default.Dir) > 0 {
+		t.Errorf("except build.Default unchanged but got tags %v dir %s", build.Default.BuildTags, build.Default.Dir)
+	}
+}
@@ -1,7 +1,7 @@
 package parse
 
 import (
-	"path"
+	"strings"
 
 	"github.com/gogf/gf/v2/os/gfile"
 )
@@ -11,12 +11,16 @@ var globalModel = map[string]*Model{}
 type ModelType struct {
 	Raw     interface{}
 	Content []byte
+	// 与类型关联的常量块和方法, 其代码已追加在 Content 中
+	Consts  []*ConstBlock
+	Methods []*Function
 }
 
 type Model struct {
 	filename string
 	imports  map[string]string
 	types    map[string]*ModelType
+	files    []*File
 }
 
 func (m *Model) GetFileName() string {
@@ -69,18 +73,21 @@ func ParseFileModel(filename string) (*Model, error) {
 		}
 		decodeAstFile(f, model)
 	}
+	model.link()
 	globalModel[filename] = model
 	return model, nil
 }
 
-func ParsePackageModel(dir string) (*Model, error) {
+// ParsePackageModel 解析目录下参与构建的所有 Go 文件, tags 为额外的构建标签
+func ParsePackageModel(dir string, tags ...string) (*Model, error) {
 	dir = formatPath(dir)
-	// 进行全局缓存
-	if m, ok := globalModel[dir]; ok {
+	// 进行全局缓存, 不同的构建标签得到的文件不同
+	key := dir + "#" + strings.Join(tags, ",")
+	if m, ok := globalModel[key]; ok {
 		return m, nil
 	}
 
-	files, err := listFile(dir)
+	files, err := ListGoFiles(dir, tags...)
 	if err != nil {
 		return nil, err
 	}
@@ -90,26 +97,26 @@ func ParsePackageModel(dir string) (*Model, error) {
 		imports:  map[string]string{},
 	}
 	for _, file := range files {
-		if path.Ext(file) != ".go" {
-			continue
-		}
 		f, err := ParseFile(file)
 		if err != nil {
 			return nil, err
 		}
 		decodeAstFile(f, model)
 	}
-	globalModel[dir] = model
+	model.link()
+	globalModel[key] = model
 	return model, nil
 }
 
 func decodeAstFile(f *File, model *Model) {
+	model.files = append(model.files, f)
 	content := f.Content
 	for _, v := range f.Imports {
 		model.imports[v.Export] = v.Path
 	}
 	for _, it := range f.InterfaceTypes {
 		var bytes []byte
+		bytes = append(bytes, DocWithDirectives(it.Doc, it.Directives)...)
 		bytes = append(bytes, []byte("type ")...)
 		bytes = append(bytes, content[it.Pos-1:it.End-1]...)
 		model.AddType(it.Name, &ModelType{
@@ -119,6 +126,7 @@ func decodeAstFile(f *File, model *Model) {
 	}
 	for _, st := range f.StructTypes {
 		var bytes []byte
+		bytes = append(bytes, DocWithDirectives(st.Doc, st.Directives)...)
 		bytes = append(bytes, []byte("type ")...)
 		bytes = append(bytes, content[st.Pos-1:st.End-1]...)
 		model.AddType(st.Name, &ModelType{
@@ -126,4 +134,39 @@ func decodeAstFile(f *File, model *Model) {
 			Content: bytes,
 		})
 	}
+	for _, nt := range f.NamedTypes {
+		var bytes []byte
+		bytes = append(bytes, DocWithDirectives(nt.Doc, nt.Directives)...)
+		bytes = append(bytes, []byte("type ")...)
+		bytes = append(bytes, content[nt.Pos-1:nt.End-1]...)
+		model.AddType(nt.Name, &ModelType{
+			Raw:     nt,
+			Content: bytes,
+		})
+	}
+}
+
+// link 将常量块和方法关联到对应的类型上, 类型可能与它们声明在不同的文件中
+func (m *Model) link() {
+	for _, f := range m.files {
+		for _, block := range f.ConstBlocks {
+			tpe := m.types[block.Type]
+			if tpe == nil {
+				continue
+			}
+			tpe.Consts = append(tpe.Consts, block)
+			tpe.Content = append(tpe.Content, "\n\n"...)
+			tpe.Content = append(tpe.Content, f.Content[block.Pos-1:block.End-1]...)
+		}
+		for _, fun := range f.Functions {
+			tpe := m.types[fun.RecvTypeName]
+			if len(fun.RecvTypeName) == 0 || tpe == nil {
+				continue
+			}
+			tpe.Methods = append(tpe.Methods, fun)
+			tpe.Content = append(tpe.Content, "\n\n"...)
+			tpe.Content = append(tpe.Content, DocComment(fun.Doc)...)
+			tpe.Content = append(tpe.Content, f.Content[fun.Pos-1:fun.End-1]...)
+		}
+	}
 }
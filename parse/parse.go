@@ -19,7 +19,7 @@ func ParseFile(filename string) (*File, error) {
 // ParseFile 解析文件语法树, 不对Struct的方法整合
 func ParseContent(filename string, content []byte) (*File, error) {
 	fset := token.NewFileSet()
-	f, err := parser.ParseFile(fset, filename, content, 0)
+	f, err := parser.ParseFile(fset, filename, content, parser.ParseComments)
 	if err != nil {
 		return nil, err
 	}
@@ -37,8 +37,7 @@ func ParseContent(filename string, content []byte) (*File, error) {
 		if len(imp.Name) != 0 {
 			imp.Export = imp.Name
 		} else {
-			index := strings.LastIndex(imp.Path, "/") + 1
-			imp.Export = imp.Path[index:]
+			imp.Export = GuessPackageName(imp.Path)
 		}
 		res.Imports = append(res.Imports, imp)
 	}
@@ -47,13 +46,38 @@ func ParseContent(filename string, content []byte) (*File, error) {
 		case *ast.FuncDecl:
 			res.Functions = append(res.Functions, parseFunctionType(content, n))
 		case *ast.GenDecl:
+			if n.Tok == token.CONST {
+				res.ConstBlocks = append(res.ConstBlocks, parseConstBlock(content, n))
+				continue
+			}
 			for _, s := range n.Specs {
 				if ts, ok := s.(*ast.TypeSpec); ok {
+					// 单独声明的类型, 注释在 GenDecl 上
+					doc := ts.Doc
+					if doc == nil && len(n.Specs) == 1 {
+						doc = n.Doc
+					}
 					switch ts.Type.(type) {
 					case *ast.StructType:
-						res.StructTypes = append(res.StructTypes, parseStructType(content, ts))
+						st := parseStructType(content, ts)
+						st.Doc = docText(doc)
+						st.Directives = parseDirectives(doc)
+						res.StructTypes = append(res.StructTypes, st)
 					case *ast.InterfaceType:
-						res.InterfaceTypes = append(res.InterfaceTypes, parseInterfaceType(content, ts))
+						it := parseInterfaceType(content, ts)
+						it.Doc = docText(doc)
+						it.Directives = parseDirectives(doc)
+						res.InterfaceTypes = append(res.InterfaceTypes, it)
+					default:
+						res.NamedTypes = append(res.NamedTypes, &NamedType{
+							Pos:        ts.Pos(),
+							End:        ts.End(),
+							Name:       ts.Name.Name,
+							Doc:        docText(doc),
+							Directives: parseDirectives(doc),
+							TypeRaw:    ts.Type,
+							TypeParams: typeParams(ts),
+						})
 					}
 				}
 			}
@@ -75,6 +99,12 @@ func setFileAstParent(file *File) {
 		s.Parent = file
 		fields = append(fields, s.Fields...)
 	}
+	for _, n := range file.NamedTypes {
+		n.Parent = file
+	}
+	for _, c := range file.ConstBlocks {
+		c.Parent = file
+	}
 	for _, i := range file.InterfaceTypes {
 		i.Parent = file
 		for _, f := range i.Functions {
@@ -82,6 +112,7 @@ func setFileAstParent(file *File) {
 			fields = append(fields, f.Params...)
 			fields = append(fields, f.Results...)
 		}
+		fields = append(fields, i.Embeds...)
 	}
 	for _, field := range fields {
 		field.Parent = file
@@ -90,42 +121,128 @@ func setFileAstParent(file *File) {
 
 func parseStructType(content []byte, spec *ast.TypeSpec) *StructType {
 	result := &StructType{
-		Pos:  spec.Pos(),
-		End:  spec.End(),
-		Name: spec.Name.Name,
+		Pos:        spec.Pos(),
+		End:        spec.End(),
+		Name:       spec.Name.Name,
+		TypeRaw:    spec.Type,
+		TypeParams: typeParams(spec),
 	}
 	structType := spec.Type.(*ast.StructType)
 	if structType.Fields != nil && structType.Fields.List != nil {
 		for _, cur := range structType.Fields.List {
-			field := &Field{}
-			field.Pos = cur.Pos()
-			if len(cur.Names) > 0 {
-				field.Name = cur.Names[0].Name
+			tpe := string(content[cur.Type.Pos()-1 : cur.Type.End()-1])
+			var tag Tag
+			if cur.Tag != nil {
+				tag = Tag(cur.Tag.Value)
 			}
-			if cur.Type != nil {
-				field.Type = string(content[cur.Type.Pos()-1 : cur.Type.End()-1])
-				field.TypeRaw = cur.Type
+			// 嵌入字段
+			if len(cur.Names) == 0 {
+				result.Fields = append(result.Fields, &Field{
+					Pos:      cur.Pos(),
+					Name:     EmbeddedFieldName(cur.Type),
+					Doc:      fieldDocText(cur),
+					Type:     tpe,
+					TypeRaw:  cur.Type,
+					Tag:      tag,
+					Embedded: true,
+				})
+				continue
 			}
-			if cur.Tag != nil {
-				field.Tag = Tag(cur.Tag.Value)
+			// A, B int 拆分成多个字段, 共用同一个类型表达式
+			for _, n := range cur.Names {
+				result.Fields = append(result.Fields, &Field{
+					Pos:     n.Pos(),
+					Name:    n.Name,
+					Doc:     fieldDocText(cur),
+					Type:    tpe,
+					TypeRaw: cur.Type,
+					Tag:     tag,
+				})
 			}
-			result.Fields = append(result.Fields, field)
 		}
 	}
 	return result
 }
 
+func parseConstBlock(content []byte, decl *ast.GenDecl) *ConstBlock {
+	block := &ConstBlock{
+		Pos: decl.Pos(),
+		End: decl.End(),
+		Raw: decl,
+	}
+	var lastType string
+	for _, s := range decl.Specs {
+		spec := s.(*ast.ValueSpec)
+		// 省略类型和值时沿用上一个常量的类型, 只有值时为无类型常量
+		tpe := lastType
+		if spec.Type != nil {
+			tpe = string(content[spec.Type.Pos()-1 : spec.Type.End()-1])
+		} else if len(spec.Values) > 0 {
+			tpe = ""
+		}
+		lastType = tpe
+		if len(block.Type) == 0 {
+			block.Type = tpe
+		}
+		for i, n := range spec.Names {
+			c := &Const{
+				Pos:  n.Pos(),
+				Name: n.Name,
+				Type: tpe,
+			}
+			if i < len(spec.Values) {
+				c.Value = string(content[spec.Values[i].Pos()-1 : spec.Values[i].End()-1])
+			}
+			block.Consts = append(block.Consts, c)
+		}
+	}
+	return block
+}
+
+// EmbeddedFieldName 嵌入字段的字段名, 即去掉指针和包名后的类型名称
+// e.g. *model.Audit => Audit, Page[T] => Page
+func EmbeddedFieldName(expr ast.Expr) string {
+	switch n := expr.(type) {
+	case *ast.Ident:
+		return n.Name
+	case *ast.StarExpr:
+		return EmbeddedFieldName(n.X)
+	case *ast.SelectorExpr:
+		return n.Sel.Name
+	case *ast.IndexExpr:
+		return EmbeddedFieldName(n.X)
+	case *ast.IndexListExpr:
+		return EmbeddedFieldName(n.X)
+	}
+	return ""
+}
+
 func parseInterfaceType(content []byte, spec *ast.TypeSpec) *InterfaceType {
 	interfaceType := spec.Type.(*ast.InterfaceType)
 	tpe := &InterfaceType{
-		Pos:  spec.Pos(),
-		End:  spec.End(),
-		Name: spec.Name.Name,
+		Pos:        spec.Pos(),
+		End:        spec.End(),
+		Name:       spec.Name.Name,
+		TypeRaw:    spec.Type,
+		TypeParams: typeParams(spec),
 	}
 	for _, m := range interfaceType.Methods.List {
+		// 嵌入的接口
+		if len(m.Names) == 0 {
+			tpe.Embeds = append(tpe.Embeds, &Field{
+				Pos:     m.Type.Pos(),
+				Type:    string(content[m.Type.Pos()-1 : m.Type.End()-1]),
+				TypeRaw: m.Type,
+			})
+			continue
+		}
 		fun := &Function{
 			Pos:  m.Pos(),
+			End:  m.End(),
 			Name: m.Names[0].Name,
+			Doc:  fieldDocText(m),
+			// 指令只能写在文档注释中
+			Directives: parseDirectives(m.Doc),
 		}
 		fun.Params, fun.Results = parseFunctionParamAndResult(content, m.Type.(*ast.FuncType))
 		tpe.Functions = append(tpe.Functions, fun)
@@ -137,8 +254,11 @@ func parseFunctionType(content []byte, funcDecl *ast.FuncDecl) *Function {
 	fun := &Function{
 		Pos: funcDecl.Pos(),
 		End: funcDecl.End(),
+		Raw: funcDecl,
 	}
 	fun.Name = funcDecl.Name.Name
+	fun.Doc = docText(funcDecl.Doc)
+	fun.Directives = parseDirectives(funcDecl.Doc)
 	fun.RecvTypeName = getRecvTypeName(content, funcDecl)
 	fun.Params, fun.Results = parseFunctionParamAndResult(content, funcDecl.Type)
 	return fun
@@ -151,9 +271,90 @@ func getRecvTypeName(content []byte, funcDecl *ast.FuncDecl) string {
 	recv := funcDecl.Recv.List[0]
 	recvType := string(content[recv.Type.Pos()-1 : recv.Type.End()-1])
 	recvTypeName := strings.ReplaceAll(recvType, "*", "")
+	// 泛型类型的接收者 Page[T] => Page
+	if index := strings.Index(recvTypeName, "["); index >= 0 {
+		recvTypeName = recvTypeName[:index]
+	}
 	return strings.TrimSpace(recvTypeName)
 }
 
+// docText 注释的文本内容, 不包含注释符号和 //go: 之类的指令
+func docText(doc *ast.CommentGroup) string {
+	if doc == nil {
+		return ""
+	}
+	return strings.TrimSpace(doc.Text())
+}
+
+// parseDirectives 解析注释中的 //sr: 指令, 指令与注释符号之间不能有空格
+func parseDirectives(doc *ast.CommentGroup) []*Directive {
+	if doc == nil {
+		return nil
+	}
+	var list []*Directive
+	for _, c := range doc.List {
+		if !strings.HasPrefix(c.Text, directivePrefix) {
+			continue
+		}
+		text := strings.TrimSpace(c.Text[len(directivePrefix):])
+		name, args, _ := strings.Cut(text, " ")
+		list = append(list, &Directive{
+			Pos:  c.Pos(),
+			Name: name,
+			Args: strings.TrimSpace(args),
+		})
+	}
+	return list
+}
+
+// DocWithDirectives 将注释文本和指令还原为注释代码, 指令按惯例放在最后
+func DocWithDirectives(doc string, directives []*Directive) string {
+	result := DocComment(doc)
+	if len(result) > 0 && len(directives) > 0 {
+		result += "//\n"
+	}
+	for _, d := range directives {
+		result += directivePrefix + d.Name
+		if len(d.Args) > 0 {
+			result += " " + d.Args
+		}
+		result += "\n"
+	}
+	return result
+}
+
+// fieldDocText 字段的注释, 没有文档注释时使用行尾注释
+func fieldDocText(field *ast.Field) string {
+	if field.Doc != nil {
+		return docText(field.Doc)
+	}
+	return docText(field.Comment)
+}
+
+// DocComment 将注释文本还原为 // 注释代码, 以换行结尾
+func DocComment(doc string) string {
+	if len(doc) == 0 {
+		return ""
+	}
+	var sb strings.Builder
+	for _, line := range strings.Split(doc, "\n") {
+		if len(line) == 0 {
+			sb.WriteString("//\n")
+			continue
+		}
+		sb.WriteString("// " + line + "\n")
+	}
+	return sb.String()
+}
+
+// typeParams 类型声明的类型参数列表, 返回 interface{} 以便非泛型时为 nil
+func typeParams(spec *ast.TypeSpec) interface{} {
+	if spec.TypeParams == nil {
+		return nil
+	}
+	return spec.TypeParams
+}
+
 func parseFunctionParamAndResult(content []byte, funType *ast.FuncType) (params []*Field, results []*Field) {
 	if funType.Params != nil {
 		fparams := funType.Params.List
@@ -214,6 +415,60 @@ func CombineStructTypes(files []*File) []*StructType {
 	return arr
 }
 
+// CombineInterfaceTypes 将同一批文件中声明的嵌入接口的方法合并到接口中,
+// 无法在这些文件中找到的嵌入接口会保留在 Embeds 里
+func CombineInterfaceTypes(files []*File) []*InterfaceType {
+	var arr []*InterfaceType
+	interfaceMap := map[string]*InterfaceType{}
+	for _, f := range files {
+		for _, it := range f.InterfaceTypes {
+			interfaceMap[it.Name] = it
+			arr = append(arr, it)
+		}
+	}
+	expanded := map[*InterfaceType]bool{}
+	var expand func(it *InterfaceType, visiting map[*InterfaceType]bool)
+	expand = func(it *InterfaceType, visiting map[*InterfaceType]bool) {
+		if expanded[it] || visiting[it] {
+			return
+		}
+		visiting[it] = true
+		var embeds []*Field
+		for _, embed := range it.Embeds {
+			target := interfaceMap[embed.Type]
+			if target == nil {
+				embeds = append(embeds, embed)
+				continue
+			}
+			expand(target, visiting)
+			it.Functions = MergeFunctions(it.Functions, target.Functions)
+			embeds = append(embeds, target.Embeds...)
+		}
+		it.Embeds = embeds
+		expanded[it] = true
+	}
+	for _, it := range arr {
+		expand(it, map[*InterfaceType]bool{})
+	}
+	return arr
+}
+
+// MergeFunctions 合并方法列表, 同名的方法只保留第一个
+func MergeFunctions(list []*Function, others []*Function) []*Function {
+	names := map[string]bool{}
+	for _, f := range list {
+		names[f.Name] = true
+	}
+	for _, f := range others {
+		if names[f.Name] {
+			continue
+		}
+		names[f.Name] = true
+		list = append(list, f)
+	}
+	return list
+}
+
 func GetSlotStruct([]*StructType) []*StructType {
 	return nil
 }
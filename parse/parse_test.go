@@ -207,3 +207,283 @@ func TestParseFunctionType(t *testing.T) {
 	}
 	fmt.Println(f)
 }
+
+func TestParseEmbeddedInterface(t *testing.T) {
+	f1, err := ParseContent("a.go", []byte(`
+	package main
+
+	type IPerson interface {
+		IWalker
+		io.Closer
+		SayHello(foo string) error
+	}
+	`))
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	f2, err := ParseContent("b.go", []byte(`
+	package main
+
+	type IWalker interface {
+		Walk() error
+		SayHello(foo string) error
+	}
+	`))
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	if len(f1.InterfaceTypes[0].Embeds) != 2 {
+		t.Errorf("except embedded interface quantity = 2, but got %d", len(f1.InterfaceTypes[0].Embeds))
+		return
+	}
+	list := CombineInterfaceTypes([]*File{f1, f2})
+	person := list[0]
+	if len(person.Functions) != 2 {
+		t.Errorf("except interface function quantity = 2, but got %d", len(person.Functions))
+		return
+	}
+	if person.Functions[1].Name != "Walk" || person.Functions[1].Parent != f2 {
+		t.Errorf("except embedded function Walk from b.go, but got %s", person.Functions[1].Name)
+		return
+	}
+	// 其他包中的接口保留待后续展开
+	if len(person.Embeds) != 1 || person.Embeds[0].Type != "io.Closer" {
+		t.Errorf("except remaining embed io.Closer, but got %v", person.Embeds)
+		return
+	}
+}
+
+func TestParseStructFields(t *testing.T) {
+	f, err := ParseContent("test.go", []byte(`
+	package main
+
+	type Foo struct {
+		model.Audit
+		*Base
+		A, B string `+"`json:\"ab\"`"+`
+	}
+	`))
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	fields := f.StructTypes[0].Fields
+	if len(fields) != 4 {
+		t.Errorf("except struct field count = 4, but got %d", len(fields))
+		return
+	}
+	if !fields[0].Embedded || fields[0].Name != "Audit" {
+		t.Errorf("except embedded field Audit, but got %s", fields[0].Name)
+		return
+	}
+	if !fields[1].Embedded || fields[1].Name != "Base" || fields[1].Type != "*Base" {
+		t.Errorf("except embedded field Base, but got %s", fields[1].Name)
+		return
+	}
+	if fields[3].Name != "B" || fields[3].Type != "string" || fields[3].Tag.Get("json") != "ab" {
+		t.Errorf("except field B string, but got %s %s", fields[3].Name, fields[3].Type)
+		return
+	}
+}
+
+func TestParseConstBlock(t *testing.T) {
+	f, err := ParseContent("test.go", []byte(`
+	package main
+
+	type Status int
+
+	const (
+		StatusA Status = iota + 1
+		StatusB
+		Max = 10
+	)
+	`))
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	if len(f.NamedTypes) != 1 || f.NamedTypes[0].Name != "Status" {
+		t.Errorf("except named type Status")
+		return
+	}
+	block := f.ConstBlocks[0]
+	if block.Type != "Status" || len(block.Consts) != 3 {
+		t.Errorf("except const block of Status with 3 constants, but got %s %d", block.Type, len(block.Consts))
+		return
+	}
+	if block.Consts[1].Type != "Status" || block.Consts[2].Type != "" {
+		t.Errorf("except StatusB typed and Max untyped, but got %s %s", block.Consts[1].Type, block.Consts[2].Type)
+		return
+	}
+	values := EvalConstValues("type Status int\n\nconst (\n\tA Status = iota + 1\n\tB\n)")
+	if values["B"] != "2" {
+		t.Errorf("except B = 2, but got %s", values["B"])
+		return
+	}
+}
+
+func TestParseGenericType(t *testing.T) {
+	f, err := ParseContent("test.go", []byte(`
+	package main
+
+	type Page[T any] struct {
+		Items []T
+	}
+
+	func (p *Page[T]) Len() int {
+		return len(p.Items)
+	}
+	`))
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	if f.StructTypes[0].TypeParams == nil {
+		t.Errorf("except struct Page has type params")
+		return
+	}
+	if f.Functions[0].RecvTypeName != "Page" {
+		t.Errorf("except recv type name = Page, but got %s", f.Functions[0].RecvTypeName)
+		return
+	}
+}
+
+func TestParseDoc(t *testing.T) {
+	f, err := ParseContent("test.go", []byte(`
+	package main
+
+	// User 用户
+	type User struct {
+		// 名称
+		Name string
+		Age  int // 年龄
+	}
+
+	type (
+		// Status 状态
+		Status int
+	)
+
+	// IUser 用户接口
+	type IUser interface {
+		// Get 获取用户
+		Get() User
+	}
+
+	// Hello 问候
+	//go:noinline
+	func Hello() {}
+	`))
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	st := f.StructTypes[0]
+	if st.Doc != "User 用户" {
+		t.Errorf("except struct doc 'User 用户' but got '%s'", st.Doc)
+		return
+	}
+	if st.Fields[0].Doc != "名称" || st.Fields[1].Doc != "年龄" {
+		t.Errorf("except field doc '名称' '年龄' but got '%s' '%s'", st.Fields[0].Doc, st.Fields[1].Doc)
+		return
+	}
+	if f.NamedTypes[0].Doc != "Status 状态" {
+		t.Errorf("except named type doc 'Status 状态' but got '%s'", f.NamedTypes[0].Doc)
+		return
+	}
+	it := f.InterfaceTypes[0]
+	if it.Doc != "IUser 用户接口" || it.Functions[0].Doc != "Get 获取用户" {
+		t.Errorf("except interface doc but got '%s' '%s'", it.Doc, it.Functions[0].Doc)
+		return
+	}
+	if f.Functions[0].Doc != "Hello 问候" {
+		t.Errorf("except function doc 'Hello 问候' but got '%s'", f.Functions[0].Doc)
+		return
+	}
+	if DocComment("a\n\nb") != "// a\n//\n// b\n" {
+		t.Errorf("except doc comment but got '%s'", DocComment("a\n\nb"))
+		return
+	}
+}
+
+func TestListGoFiles(t *testing.T) {
+	files, err := ListGoFiles("testdata/build")
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	if len(files) != 1 || files[0] != "testdata/build/a.go" {
+		t.Errorf("except [testdata/build/a.go] but got %v", files)
+		return
+	}
+	files, err = ListGoFiles("testdata/build", "pro")
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	if len(files) != 2 || files[1] != "testdata/build/pro.go" {
+		t.Errorf("except a.go and pro.go but got %v", files)
+		return
+	}
+	model, err := ParsePackageModel("testdata/build")
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	if model.ContainsType("Pro") || !model.ContainsType("A") {
+		t.Errorf("except only type A in package model")
+		return
+	}
+}
+
+func TestParseDirectives(t *testing.T) {
+	f, err := ParseContent("test.go", []byte(`
+	package main
+
+	// Get 获取
+	//
+	//sr:name Fetch
+	//sr:deprecated use List instead
+	func (s *sUser) Get() {}
+	`))
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	fun := f.Functions[0]
+	if fun.Doc != "Get 获取" {
+		t.Errorf("except doc without directives but got '%s'", fun.Doc)
+		return
+	}
+	if len(fun.Directives) != 2 {
+		t.Errorf("except 2 directives but got %d", len(fun.Directives))
+		return
+	}
+	d := FindDirective(fun.Directives, "deprecated")
+	if d == nil || d.Args != "use List instead" {
+		t.Errorf("except deprecated directive with message")
+		return
+	}
+	if FindDirective(fun.Directives, "ignore") != nil {
+		t.Errorf("except no ignore directive")
+		return
+	}
+}
+
+func TestGuessPackageName(t *testing.T) {
+	cases := map[string]string{
+		"context":                        "context",
+		"abc/internal/model":             "model",
+		"github.com/go-redis/redis/v8":   "redis",
+		"gopkg.in/yaml.v3":               "yaml",
+		"github.com/mattn/go-sqlite3":    "sqlite3",
+		"github.com/influxdata/line-api": "lineapi",
+	}
+	for path, except := range cases {
+		if name := GuessPackageName(path); name != except {
+			t.Errorf("except package name %s for %s but got %s", except, path, name)
+		}
+	}
+}
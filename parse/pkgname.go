@@ -0,0 +1,63 @@
+package parse
+
+import (
+	"go/build"
+	"regexp"
+	"strings"
+	"unicode"
+)
+
+var importedPackages = map[string]*build.Package{}
+
+var majorVersionReg = regexp.MustCompile(`^v[0-9]+$`)
+var gopkgVersionReg = regexp.MustCompile(`\.v[0-9]+$`)
+
+// importPackage 在 dir 所在的模块中查找导入路径对应的包, 找不到时返回 nil
+func importPackage(importPath string, dir string) *build.Package {
+	if pkg, ok := importedPackages[importPath]; ok {
+		return pkg
+	}
+	ctx := build.Default
+	ctx.Dir = absPath(dir)
+	pkg, err := ctx.Import(importPath, ctx.Dir, 0)
+	if err != nil || len(pkg.Name) == 0 {
+		pkg = nil
+	}
+	importedPackages[importPath] = pkg
+	return pkg
+}
+
+// PackageName 导入路径对应的包名, 优先从包的源码中读取, dir 为导入所在的目录, 无法加载包时根据路径推断
+func PackageName(importPath string, dir string) string {
+	if pkg := importPackage(importPath, dir); pkg != nil {
+		return pkg.Name
+	}
+	return GuessPackageName(importPath)
+}
+
+// PackageDir 导入路径对应的包的源码目录, 第三方包位于模块缓存中, 找不到时返回空字符串
+func PackageDir(importPath string, dir string) string {
+	if pkg := importPackage(importPath, dir); pkg != nil {
+		return formatPath(pkg.Dir)
+	}
+	return ""
+}
+
+// GuessPackageName 根据导入路径推断包名, 忽略主版本号和 gopkg.in 的版本后缀,
+// e.g. github.com/go-redis/redis/v8 => redis, gopkg.in/yaml.v3 => yaml
+func GuessPackageName(importPath string) string {
+	parts := strings.Split(importPath, "/")
+	name := parts[len(parts)-1]
+	if majorVersionReg.MatchString(name) && len(parts) > 1 {
+		name = parts[len(parts)-2]
+	}
+	name = gopkgVersionReg.ReplaceAllString(name, "")
+	name = strings.TrimPrefix(name, "go-")
+	name = strings.TrimSuffix(strings.TrimSuffix(name, "-go"), ".go")
+	return strings.Map(func(r rune) rune {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) || r == '_' {
+			return r
+		}
+		return -1
+	}, name)
+}
@@ -0,0 +1,5 @@
+package build
+
+import "testing"
+
+func TestA(t *testing.T) {}
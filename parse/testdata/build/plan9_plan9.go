@@ -0,0 +1,3 @@
+package build
+
+type Plan9 struct{}
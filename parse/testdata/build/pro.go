@@ -0,0 +1,5 @@
+//go:build pro
+
+package build
+
+type Pro struct{}
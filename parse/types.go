@@ -13,24 +13,77 @@ type File struct {
 	Imports        []*Import
 	InterfaceTypes []*InterfaceType
 	StructTypes    []*StructType
+	NamedTypes     []*NamedType
+	ConstBlocks    []*ConstBlock
 	Functions      []*Function
 }
 
 type InterfaceType struct {
-	Parent    *File
-	Pos       token.Pos
-	End       token.Pos
-	Name      string
-	Functions []*Function
+	Parent *File
+	Pos    token.Pos
+	End    token.Pos
+	Name   string
+	// 类型的文档注释, 不包含注释符号
+	Doc string
+	// 文档注释中的 //sr: 指令
+	Directives []*Directive
+	TypeRaw    interface{}
+	// 泛型的类型参数列表 *ast.FieldList, 非泛型时为 nil
+	TypeParams interface{}
+	Functions  []*Function
+	// 嵌入的接口, 展开后其方法会合并到 Functions 中
+	Embeds []*Field
 }
 
 type StructType struct {
-	Parent    *File
-	Pos       token.Pos
-	End       token.Pos
-	Name      string
-	Fields    []*Field
-	Functions []*Function
+	Parent *File
+	Pos    token.Pos
+	End    token.Pos
+	Name   string
+	// 类型的文档注释, 不包含注释符号
+	Doc string
+	// 文档注释中的 //sr: 指令
+	Directives []*Directive
+	TypeRaw    interface{}
+	// 泛型的类型参数列表 *ast.FieldList, 非泛型时为 nil
+	TypeParams interface{}
+	Fields     []*Field
+	Functions  []*Function
+}
+
+// NamedType 结构体和接口以外的类型声明, e.g. type OrderStatus int
+type NamedType struct {
+	Parent *File
+	Pos    token.Pos
+	End    token.Pos
+	Name   string
+	// 类型的文档注释, 不包含注释符号
+	Doc string
+	// 文档注释中的 //sr: 指令
+	Directives []*Directive
+	TypeRaw    interface{}
+	// 泛型的类型参数列表 *ast.FieldList, 非泛型时为 nil
+	TypeParams interface{}
+}
+
+// ConstBlock 一个 const 声明块
+type ConstBlock struct {
+	Parent *File
+	Pos    token.Pos
+	End    token.Pos
+	// 块中第一个有类型的常量的类型, 类型的可选值以此关联
+	Type   string
+	Consts []*Const
+	Raw    interface{}
+}
+
+type Const struct {
+	Pos  token.Pos
+	Name string
+	// 省略类型时沿用上一个常量的类型, 无类型常量为空
+	Type string
+	// 源码中的值表达式, 省略时为空
+	Value string
 }
 
 type Tag string
@@ -45,22 +98,49 @@ func (t Tag) Get(key string) string {
 }
 
 type Function struct {
-	Parent       *File
-	Pos          token.Pos
-	End          token.Pos
-	Name         string
+	Parent *File
+	Pos    token.Pos
+	End    token.Pos
+	Name   string
+	Doc    string
+	// 文档注释中的 //sr: 指令
+	Directives   []*Directive
 	RecvTypeName string
 	Params       []*Field
 	Results      []*Field
+	Raw          interface{}
+}
+
+const directivePrefix = "//sr:"
+
+// Directive 注释中的指令, e.g. //sr:name Create => {Name: "name", Args: "Create"}
+type Directive struct {
+	Pos  token.Pos
+	Name string
+	Args string
+}
+
+// FindDirective 查找指定名称的指令, 不存在时返回 nil
+func FindDirective(list []*Directive, name string) *Directive {
+	for _, v := range list {
+		if v.Name == name {
+			return v
+		}
+	}
+	return nil
 }
 
 type Field struct {
-	Parent  *File
-	Pos     token.Pos
-	Name    string
+	Parent *File
+	Pos    token.Pos
+	Name   string
+	// 字段的文档注释, 没有时为行尾注释
+	Doc     string
 	Type    string
 	TypeRaw interface{}
 	Tag     Tag
+	// 嵌入字段, Name 为类型名称
+	Embedded bool
 }
 
 type Import struct {
@@ -0,0 +1,10 @@
+//go:build go1.22
+
+package parse
+
+import "go/types"
+
+// unalias 别名类型在 go1.22 起可能表示为 *types.Alias, 取得实际的类型
+func unalias(t types.Type) types.Type {
+	return types.Unalias(t)
+}
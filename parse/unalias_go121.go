@@ -0,0 +1,10 @@
+//go:build !go1.22
+
+package parse
+
+import "go/types"
+
+// unalias go1.22 之前别名类型直接表示为实际的类型
+func unalias(t types.Type) types.Type {
+	return t
+}
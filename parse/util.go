@@ -2,6 +2,7 @@ package parse
 
 import (
 	"go/ast"
+	"go/build"
 	"io/ioutil"
 	"path"
 	"runtime"
@@ -17,23 +18,27 @@ func formatPath(path string) string {
 	return path
 }
 
-func listFile(dirname string, deep ...bool) ([]string, error) {
-	fileInfos, err := ioutil.ReadDir(dirname)
+// ListGoFiles 列出目录下参与构建的 Go 源文件, 跳过测试文件以及
+// 不满足构建约束 (文件名后缀 _linux, _amd64 和 //go:build) 的文件, tags 为额外的构建标签
+func ListGoFiles(dir string, tags ...string) ([]string, error) {
+	fileInfos, err := ioutil.ReadDir(dir)
 	if err != nil {
 		return nil, err
 	}
+	ctx := build.Default
+	ctx.BuildTags = tags
 	var list []string
 	for _, fi := range fileInfos {
-		filename := path.Join(dirname, fi.Name())
-		if fi.IsDir() && len(deep) > 0 && deep[0] {
-			//继续遍历fi这个目录
-			files, err := listFile(filename, true)
-			if err != nil {
-				return nil, err
-			}
-			list = append(list, files...)
-		} else {
-			list = append(list, filename)
+		name := fi.Name()
+		if fi.IsDir() || path.Ext(name) != ".go" || strings.HasSuffix(name, "_test.go") {
+			continue
+		}
+		match, err := ctx.MatchFile(dir, name)
+		if err != nil {
+			return nil, err
+		}
+		if match {
+			list = append(list, path.Join(dir, name))
 		}
 	}
 	return list, nil
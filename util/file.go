@@ -62,6 +62,10 @@ func RemoveGenerateFiles(dir string) error {
 		return err
 	}
 	for _, f := range files {
+		// 映射文件随生成文件一起删除
+		if StringEndOf(f, SourceMapExt) {
+			continue
+		}
 		is, err := IsGenerateFile(f)
 		if err != nil {
 			return err
@@ -71,6 +75,12 @@ func RemoveGenerateFiles(dir string) error {
 			if err != nil {
 				return err
 			}
+			if gfile.Exists(SourceMapFileName(f)) {
+				err = gfile.Remove(SourceMapFileName(f))
+				if err != nil {
+					return err
+				}
+			}
 		}
 	}
 	return nil
@@ -129,6 +139,28 @@ func TryConvRelPath(basepath, targetpath string) string {
 	return out
 }
 
+// FindProjectRoot 从指定目录向上查找 go.mod 所在的目录
+func FindProjectRoot(dir string) (string, error) {
+	dir, err := filepath.Abs(dir)
+	if err != nil {
+		return "", err
+	}
+	for {
+		if gfile.Exists(filepath.Join(dir, "go.mod")) {
+			return dir, nil
+		}
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return "", errors.New("not found go.mod")
+		}
+		dir = parent
+	}
+}
+
+func formatSlash(p string) string {
+	return strings.ReplaceAll(p, "\\", "/")
+}
+
 func GetGoFilePackagePath(root string, module string, filename string) (string, error) {
 	pkgPath, err := filepath.Rel(root, filename)
 	if err != nil {
@@ -0,0 +1,104 @@
+package util
+
+import (
+	"encoding/json"
+	"go/token"
+	"io/ioutil"
+	"os"
+
+	"github.com/gogf/gf/v2/os/gfile"
+)
+
+// SourceMapExt 源码映射文件的后缀, 与生成的文件放在同一目录下
+const SourceMapExt = ".map"
+
+// SourceMapping 生成代码中的一段行区间与源码声明的对应关系
+type SourceMapping struct {
+	StartLine  int    `json:"startLine"`
+	EndLine    int    `json:"endLine"`
+	Name       string `json:"name"`
+	Source     string `json:"source"`
+	Line       int    `json:"line"`
+	Column     int    `json:"column"`
+	SourceLine int    `json:"sourceEndLine"`
+}
+
+type SourceMap struct {
+	Mappings []*SourceMapping `json:"mappings"`
+}
+
+func NewSourceMap() *SourceMap {
+	return &SourceMap{}
+}
+
+// Add 记录生成代码 [start, end] 行来自于源码 pos 到 end 之间的声明
+func (m *SourceMap) Add(start, end int, name string, pos, endPos token.Position, root string) {
+	if end < start {
+		end = start
+	}
+	source := pos.Filename
+	if len(root) > 0 {
+		source = TryConvRelPath(root, source)
+	}
+	source = formatSlash(source)
+	mapping := &SourceMapping{
+		StartLine:  start,
+		EndLine:    end,
+		Name:       name,
+		Source:     source,
+		Line:       pos.Line,
+		Column:     pos.Column,
+		SourceLine: endPos.Line,
+	}
+	if mapping.SourceLine < mapping.Line {
+		mapping.SourceLine = mapping.Line
+	}
+	m.Mappings = append(m.Mappings, mapping)
+}
+
+// Find 查找包含指定行的映射, 存在嵌套时返回范围最小的一个
+func (m *SourceMap) Find(line int) *SourceMapping {
+	var result *SourceMapping
+	for _, v := range m.Mappings {
+		if line < v.StartLine || line > v.EndLine {
+			continue
+		}
+		if result == nil || v.EndLine-v.StartLine < result.EndLine-result.StartLine {
+			result = v
+		}
+	}
+	return result
+}
+
+func SourceMapFileName(filename string) string {
+	return filename + SourceMapExt
+}
+
+// WriteSourceMap 将映射写到生成文件旁边, 没有映射时删除旧的映射文件
+func WriteSourceMap(filename string, m *SourceMap) error {
+	out := SourceMapFileName(filename)
+	if m == nil || len(m.Mappings) == 0 {
+		if gfile.Exists(out) {
+			return gfile.Remove(out)
+		}
+		return nil
+	}
+	data, err := json.MarshalIndent(m, "", "  ")
+	if err != nil {
+		return err
+	}
+	return ioutil.WriteFile(out, data, os.ModePerm)
+}
+
+func ReadSourceMap(filename string) (*SourceMap, error) {
+	data, err := ioutil.ReadFile(SourceMapFileName(filename))
+	if err != nil {
+		return nil, err
+	}
+	var m *SourceMap
+	err = json.Unmarshal(data, &m)
+	if err != nil {
+		return nil, err
+	}
+	return m, nil
+}
@@ -0,0 +1,27 @@
+package util
+
+import (
+	"go/token"
+	"testing"
+)
+
+func TestSourceMapFind(t *testing.T) {
+	smap := NewSourceMap()
+	pos := token.Position{Filename: "/abc/internal/logic/order/order.go", Line: 10, Column: 1}
+	end := token.Position{Filename: "/abc/internal/logic/order/order.go", Line: 12, Column: 2}
+	smap.Add(5, 20, "sOrder", pos, end, "/abc")
+	smap.Add(8, 12, "sOrder.Create", pos, end, "/abc")
+	mapping := smap.Find(10)
+	if mapping == nil || mapping.Name != "sOrder.Create" {
+		t.Errorf("except line 10 map to sOrder.Create, but got %v", mapping)
+		return
+	}
+	if mapping.Source != "internal/logic/order/order.go" {
+		t.Errorf("except source internal/logic/order/order.go, but got %s", mapping.Source)
+		return
+	}
+	if smap.Find(21) != nil {
+		t.Errorf("except line 21 not mapped")
+		return
+	}
+}
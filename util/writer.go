@@ -2,6 +2,7 @@ package util
 
 import (
 	"bytes"
+	"strings"
 )
 
 type TextWriter interface {
@@ -62,6 +63,7 @@ func (w *textWriter) Write(p []byte) TextWriter {
 			w.lineStart = false
 		}
 		w.output.Write(p)
+		w.countLines(string(p))
 	}
 	return w
 }
@@ -76,6 +78,7 @@ func (w *textWriter) WriteString(arr ...string) TextWriter {
 			w.lineStart = false
 		}
 		w.output.WriteString(s)
+		w.countLines(s)
 	}
 	return w
 }
@@ -122,9 +125,20 @@ func (w *textWriter) RawWrite(s string) {
 			w.lineStart = false
 		}
 		w.output.WriteString(s)
+		w.countLines(s)
 	}
 }
 
+// countLines 统计直接写入的内容中的换行, 如生成文件的头部注释, 使 Line 与输出的行号一致
+func (w *textWriter) countLines(s string) {
+	n := strings.Count(s, w.newLine)
+	if n == 0 {
+		return
+	}
+	w.lineCount += n
+	w.linePos = w.output.Len() - (len(s) - strings.LastIndex(s, w.newLine) - len(w.newLine))
+}
+
 func (w *textWriter) TextPos() int {
 	return len(w.output.String())
 }